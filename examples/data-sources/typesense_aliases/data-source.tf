@@ -0,0 +1,5 @@
+data "typesense_aliases" "all" {}
+
+output "alias_targets" {
+  value = { for alias in data.typesense_aliases.all.aliases : alias.name => alias.collection_name }
+}
@@ -0,0 +1,13 @@
+data "typesense_collections" "products" {
+  name_regex = "^products_v[0-9]+$"
+}
+
+data "typesense_aliases" "all" {}
+
+# Collections that no alias points to
+output "unaliased_collections" {
+  value = setsubtract(
+    data.typesense_collections.products.names,
+    [for alias in data.typesense_aliases.all.aliases : alias.collection_name],
+  )
+}
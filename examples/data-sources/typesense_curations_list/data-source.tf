@@ -0,0 +1,7 @@
+data "typesense_curations_list" "products" {
+  collection_name = "products"
+}
+
+output "curation_names" {
+  value = [for curation in data.typesense_curations_list.products.curations : curation.name]
+}
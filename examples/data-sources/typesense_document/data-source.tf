@@ -1,4 +1,11 @@
 data "typesense_document" "my_doc" {
-  name            = "my-doc"
   collection_name = "my-collection"
+
+  document = {
+    id = "stark-industries"
+  }
+}
+
+output "num_employees" {
+  value = jsondecode(data.typesense_document.my_doc.document_json).num_employees
 }
@@ -0,0 +1,47 @@
+data "typesense_multi_search" "storefront" {
+  common_params {
+    q        = "shoe"
+    per_page = 5
+  }
+
+  searches {
+    collection_name = "products"
+    query_by        = "name,description"
+  }
+
+  searches {
+    collection_name = "brands"
+    query_by        = "name"
+  }
+
+  searches {
+    collection_name = "categories"
+    query_by        = "name"
+  }
+
+  searches {
+    collection_name = "articles"
+    query_by        = "title,body"
+    filter_by       = "published:true"
+  }
+}
+
+output "storefront_found" {
+  value = [for result in data.typesense_multi_search.storefront.results : result.found]
+}
+
+data "typesense_multi_search" "everything" {
+  union = true
+
+  searches {
+    collection_name = "products"
+    q               = "shoe"
+    query_by        = "name"
+  }
+
+  searches {
+    collection_name = "articles"
+    q               = "shoe"
+    query_by        = "title"
+  }
+}
@@ -0,0 +1,3 @@
+data "typesense_preset" "listing_view" {
+  name = "listing_view"
+}
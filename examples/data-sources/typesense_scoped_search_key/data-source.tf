@@ -0,0 +1,8 @@
+data "typesense_scoped_search_key" "tenant" {
+  parent_key = typesense_api_key.search_only.value
+
+  parameters {
+    filter_by  = "tenant_id:=123"
+    expires_at = 1906054106
+  }
+}
@@ -0,0 +1,20 @@
+data "typesense_search" "apple" {
+  collection_name = typesense_collection.products.name
+  q               = "apple"
+  query_by        = "name,description"
+  filter_by       = "in_stock:true"
+  per_page        = 10
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.document_ids) > 0 && self.document_ids[0] == "4"
+      error_message = "The curation doesn't pin document 4 to the first position."
+    }
+  }
+
+  depends_on = [typesense_curation.my_curation]
+}
+
+output "apple_titles" {
+  value = [for hit in jsondecode(data.typesense_search.apple.hits) : hit.document.name]
+}
@@ -0,0 +1,7 @@
+data "typesense_synonyms_list" "products" {
+  collection_name = "products"
+}
+
+output "synonym_names" {
+  value = [for synonym in data.typesense_synonyms_list.products.synonyms : synonym.name]
+}
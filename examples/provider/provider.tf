@@ -2,3 +2,61 @@ provider "typesense" {
   api_key     = "xxxxxxxxxxxxxxxxxx"            // Or TYPESENSE_API_KEY enivoronment variable
   api_address = "https://your.typesense.server" // Or TYPESENSE_APP_ADDRESS enivoronment variable
 }
+
+// Multi-node cluster
+provider "typesense" {
+  alias   = "cluster"
+  api_key = "xxxxxxxxxxxxxxxxxx"
+
+  nearest_node {
+    host = "xxx.a1.typesense.net"
+  }
+
+  nodes {
+    host = "xxx-1.a1.typesense.net"
+  }
+
+  nodes {
+    host = "xxx-2.a1.typesense.net"
+  }
+
+  nodes {
+    host = "xxx-3.a1.typesense.net"
+  }
+
+  num_retries            = 3
+  retry_interval_seconds = 0.5
+
+  // Back off when the whole cluster is unavailable, such as during a rolling restart
+  retry {
+    max_attempts        = 5
+    min_backoff_seconds = 1
+    max_backoff_seconds = 30
+  }
+
+  max_requests_per_second = 20
+}
+
+// Cluster behind an internal CA that requires mutual TLS
+provider "typesense" {
+  alias       = "internal"
+  api_key     = "xxxxxxxxxxxxxxxxxx"
+  api_address = "https://typesense.internal.example.com"
+
+  ca_cert_pem     = file("${path.module}/ca.pem")
+  client_cert_pem = file("${path.module}/client.pem")
+  client_key_pem  = file("${path.module}/client-key.pem")
+  proxy_url       = "http://proxy.internal.example.com:3128"
+
+  headers = {
+    "X-Gateway-Token" = "xxxxxxxxxxxxxxxxxx"
+  }
+}
+
+// Plans from untrusted pipelines with a search-only key
+provider "typesense" {
+  alias       = "plan"
+  api_key     = "xxxxxxxxxxxxxxxxxx"
+  api_address = "https://your.typesense.server"
+  read_only   = true
+}
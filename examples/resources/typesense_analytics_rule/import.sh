@@ -0,0 +1 @@
+terraform import typesense_analytics_rule.product_queries product_queries_aggregation
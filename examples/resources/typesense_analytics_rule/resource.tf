@@ -0,0 +1,52 @@
+resource "typesense_collection" "product_queries" {
+  name = "product_queries"
+
+  fields {
+    name = "q"
+    type = "string"
+  }
+
+  fields {
+    name = "count"
+    type = "int32"
+  }
+}
+
+resource "typesense_analytics_rule" "product_queries" {
+  name = "product_queries_aggregation"
+  type = "popular_queries"
+
+  params {
+    source {
+      collections = ["products"]
+    }
+
+    destination {
+      collection = typesense_collection.product_queries.name
+    }
+
+    limit = 1000
+  }
+}
+
+resource "typesense_analytics_rule" "product_clicks" {
+  name = "product_clicks"
+  type = "counter"
+
+  params {
+    source {
+      collections = ["products"]
+
+      events {
+        type   = "click"
+        name   = "products_click_event"
+        weight = 1
+      }
+    }
+
+    destination {
+      collection    = "products"
+      counter_field = "popularity"
+    }
+  }
+}
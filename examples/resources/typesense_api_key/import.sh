@@ -0,0 +1 @@
+terraform import typesense_api_key.search_only 1
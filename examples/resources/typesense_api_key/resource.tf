@@ -0,0 +1,5 @@
+resource "typesense_api_key" "search_only" {
+  description = "Search-only key for the storefront"
+  actions     = ["documents:search"]
+  collections = [typesense_collection.my_collection.name]
+}
@@ -5,4 +5,40 @@ resource "typesense_collection" "my_collection" {
     name = "name"
     type = "string"
   }
+
+  fields {
+    name  = "price"
+    type  = "float"
+    facet = true
+  }
+
+  fields {
+    name = "embedding"
+    type = "float[]"
+
+    embed {
+      from = ["name"]
+
+      model_config {
+        model_name = "ts/all-MiniLM-L12-v2"
+      }
+    }
+  }
+}
+
+resource "typesense_collection" "catalog" {
+  name = "catalog"
+
+  token_separators     = ["-"]
+  symbols_to_index     = ["+"]
+  enable_nested_fields = true
+
+  metadata = jsonencode({
+    owner = "search-team"
+  })
+
+  fields {
+    name = ".*"
+    type = "auto"
+  }
 }
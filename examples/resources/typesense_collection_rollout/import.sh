@@ -0,0 +1 @@
+terraform import typesense_collection_rollout.products products
@@ -0,0 +1,33 @@
+resource "typesense_collection_rollout" "products" {
+  alias_name = "products"
+
+  fields {
+    name = "name"
+    type = "string"
+  }
+
+  fields {
+    name  = "price"
+    type  = "float"
+    facet = true
+  }
+
+  documents {
+    file       = "${path.module}/products.jsonl"
+    batch_size = 200
+  }
+
+  min_documents = 1000
+
+  health_check {
+    q         = "shoe"
+    query_by  = "name"
+    min_found = 1
+  }
+
+  keep_generations = 2
+
+  triggers = {
+    catalog_version = "2024-06-01"
+  }
+}
@@ -7,17 +7,38 @@ resource "typesense_curation" "my_curation" {
     match = "exact"
   }
 
-  include {
-    id = 4
+  includes {
+    id       = "4"
+    position = 1
   }
 
+  includes {
+    id       = "10"
+    position = 2
+  }
 
-  include {
-    id       = 10
-    position = 100
+  excludes {
+    id = "100"
   }
+}
+
+resource "typesense_curation" "summer_sale" {
+  name            = "summer-sale"
+  collection_name = typesense_collection.my_collection.name
 
-  exclude = {
-    id = 100
+  rule {
+    tags = ["summer-sale"]
   }
+
+  filter_by             = "on_sale:true"
+  sort_by               = "discount:desc"
+  remove_matched_tokens = false
+  stop_processing       = false
+
+  effective_from = "2024-06-01T00:00:00Z"
+  effective_to   = "2024-09-01T00:00:00Z"
+
+  metadata = jsonencode({
+    banner = "summer-sale"
+  })
 }
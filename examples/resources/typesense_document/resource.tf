@@ -1,10 +1,25 @@
 resource "typesense_document" "doc" {
-  id              = "doc"
   collection_name = typesense_collection.my_collection.name
 
-  document = {
-    "company_name"  = "Stark Industries"
-    "num_employees" = 5215
-    "country"       = "USA"
-  }
+  document_json = jsonencode({
+    id            = "stark-industries"
+    company_name  = "Stark Industries"
+    num_employees = 5215
+    country       = "USA"
+    location      = [40.7484, -73.9857]
+    tags          = ["defense", "energy"]
+  })
+}
+
+# Only the changed keys are patched, so fields written by other clients are kept.
+resource "typesense_document" "stock" {
+  collection_name = typesense_collection.my_collection.name
+  action          = "emplace"
+  dirty_values    = "coerce_or_reject"
+
+  document_json = jsonencode({
+    id       = "sku-123"
+    in_stock = true
+    price    = 19.99
+  })
 }
@@ -0,0 +1,13 @@
+resource "time_rotating" "daily" {
+  rotation_days = 1
+}
+
+resource "typesense_documents_delete_by_filter" "expired_sessions" {
+  collection_name = typesense_collection.sessions.name
+  filter_by       = "expires_at:<${time_rotating.daily.unix}"
+  batch_size      = 500
+
+  triggers = {
+    rotation = time_rotating.daily.id
+  }
+}
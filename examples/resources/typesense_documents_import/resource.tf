@@ -0,0 +1,22 @@
+resource "typesense_documents_import" "countries" {
+  collection_name = typesense_collection.countries.name
+  file            = "${path.module}/countries.jsonl"
+  action          = "upsert"
+  batch_size      = 100
+  dirty_values    = "coerce_or_reject"
+
+  // Large files may take longer than the default of 30 minutes
+  timeouts {
+    create = "1h"
+    update = "1h"
+  }
+}
+
+resource "typesense_documents_import" "currencies" {
+  collection_name = typesense_collection.currencies.name
+
+  content = join("\n", [
+    jsonencode({ id = "eur", name = "Euro", decimals = 2 }),
+    jsonencode({ id = "jpy", name = "Japanese Yen", decimals = 0 }),
+  ])
+}
@@ -0,0 +1 @@
+terraform import typesense_preset.listing_view listing_view
@@ -0,0 +1,9 @@
+resource "typesense_preset" "listing_view" {
+  name = "listing_view"
+
+  value = jsonencode({
+    collection = typesense_collection.my_collection.name
+    query_by   = "name"
+    sort_by    = "price:asc"
+  })
+}
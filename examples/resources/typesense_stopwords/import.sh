@@ -0,0 +1 @@
+terraform import typesense_stopwords.english english
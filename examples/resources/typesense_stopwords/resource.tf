@@ -0,0 +1,10 @@
+resource "typesense_stopwords" "english" {
+  name   = "english"
+  locale = "en"
+
+  stopwords = [
+    "a",
+    "an",
+    "the"
+  ]
+}
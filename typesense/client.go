@@ -0,0 +1,370 @@
+package typesense
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/typesense/typesense-go/typesense"
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+// typesenseClient is the client shared by every resource and data source. It gives access to the
+// typesense-go client and adds raw access to the HTTP API for the endpoints and attributes
+// typesense-go doesn't support yet.
+type typesenseClient struct {
+	server string
+	apiKey string
+	doer   api.HttpRequestDoer
+
+	// readOnly makes creating, updating and deleting resources fail before any request is sent.
+	readOnly bool
+	// keyActions are the actions of the API key, or nil when they are unknown or unrestricted.
+	keyActions      []string
+	warnedResources sync.Map
+}
+
+func newTypesenseClient(server, apiKey string, doer api.HttpRequestDoer) (*typesenseClient, error) {
+	c := &typesenseClient{
+		server: strings.TrimSuffix(server, "/"),
+		apiKey: apiKey,
+		doer:   doer,
+	}
+
+	// The server is validated once here, so that withContext can't fail later on.
+	if _, err := c.newAPIClient(doer); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// withContext returns a typesense-go client whose requests are issued with ctx. typesense-go
+// doesn't accept a context, so without it timeouts and cancellation wouldn't abort its requests.
+func (c *typesenseClient) withContext(ctx context.Context) *typesense.Client {
+	apiClient, _ := c.newAPIClient(contextDoer{doer: c.doer, ctx: ctx})
+	return typesense.NewClient(typesense.WithAPIClient(apiClient))
+}
+
+func (c *typesenseClient) newAPIClient(doer api.HttpRequestDoer) (*api.ClientWithResponses, error) {
+	opts := []api.ClientOption{
+		api.WithHTTPClient(doer),
+	}
+
+	if c.apiKey != "" {
+		opts = append(opts, api.WithAPIKey(c.apiKey))
+	}
+
+	return api.NewClientWithResponses(c.server, opts...)
+}
+
+// contextDoer issues requests with its context instead of the one they were created with.
+type contextDoer struct {
+	doer api.HttpRequestDoer
+	ctx  context.Context
+}
+
+func (d contextDoer) Do(req *http.Request) (*http.Response, error) {
+	return d.doer.Do(req.WithContext(d.ctx))
+}
+
+// request sends a JSON request and decodes the JSON response into out when it is not nil.
+// Error responses are returned as *typesense.HTTPError like typesense-go does.
+func (c *typesenseClient) request(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	b, err := c.send(ctx, method, path, query, "application/json", body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// send sends a request with the given body and returns the body of a successful response.
+func (c *typesenseClient) send(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) ([]byte, error) {
+	u := c.server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if c.apiKey != "" {
+		req.Header.Set(api.APIKeyHeader, c.apiKey)
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, &typesense.HTTPError{Status: resp.StatusCode, Body: b}
+	}
+
+	return b, nil
+}
+
+func (c *typesenseClient) createCollection(ctx context.Context, schema *collectionSchema) (*collectionResponse, error) {
+	collection := &collectionResponse{}
+	if err := c.request(ctx, http.MethodPost, "/collections", nil, schema, collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (c *typesenseClient) retrieveCollection(ctx context.Context, name string) (*collectionResponse, error) {
+	collection := &collectionResponse{}
+	if err := c.request(ctx, http.MethodGet, "/collections/"+url.PathEscape(name), nil, nil, collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (c *typesenseClient) listCollections(ctx context.Context) ([]collectionResponse, error) {
+	collections := []collectionResponse{}
+	if err := c.request(ctx, http.MethodGet, "/collections", nil, nil, &collections); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (c *typesenseClient) updateCollection(ctx context.Context, name string, schema *collectionUpdateSchema) error {
+	return c.request(ctx, http.MethodPatch, "/collections/"+url.PathEscape(name), nil, schema, nil)
+}
+
+func (c *typesenseClient) upsertPreset(ctx context.Context, name string, p *preset) (*preset, error) {
+	res := &preset{}
+	if err := c.request(ctx, http.MethodPut, "/presets/"+url.PathEscape(name), nil, p, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *typesenseClient) retrievePreset(ctx context.Context, name string) (*preset, error) {
+	res := &preset{}
+	if err := c.request(ctx, http.MethodGet, "/presets/"+url.PathEscape(name), nil, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *typesenseClient) deletePreset(ctx context.Context, name string) error {
+	return c.request(ctx, http.MethodDelete, "/presets/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (c *typesenseClient) upsertStopwordsSet(ctx context.Context, name string, schema *stopwordsSetSchema) (*stopwordsSet, error) {
+	set := &stopwordsSet{}
+	if err := c.request(ctx, http.MethodPut, "/stopwords/"+url.PathEscape(name), nil, schema, set); err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (c *typesenseClient) retrieveStopwordsSet(ctx context.Context, name string) (*stopwordsSet, error) {
+	res := &stopwordsSetRetrieveResponse{}
+	if err := c.request(ctx, http.MethodGet, "/stopwords/"+url.PathEscape(name), nil, nil, res); err != nil {
+		return nil, err
+	}
+
+	return &res.Stopwords, nil
+}
+
+func (c *typesenseClient) deleteStopwordsSet(ctx context.Context, name string) error {
+	return c.request(ctx, http.MethodDelete, "/stopwords/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (c *typesenseClient) upsertAnalyticsRule(ctx context.Context, name string, schema *analyticsRuleSchema) (*analyticsRule, error) {
+	rule := &analyticsRule{}
+	if err := c.request(ctx, http.MethodPut, "/analytics/rules/"+url.PathEscape(name), nil, schema, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (c *typesenseClient) retrieveAnalyticsRule(ctx context.Context, name string) (*analyticsRule, error) {
+	rule := &analyticsRule{}
+	if err := c.request(ctx, http.MethodGet, "/analytics/rules/"+url.PathEscape(name), nil, nil, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (c *typesenseClient) deleteAnalyticsRule(ctx context.Context, name string) error {
+	return c.request(ctx, http.MethodDelete, "/analytics/rules/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (c *typesenseClient) upsertOverride(ctx context.Context, collectionName, name string, schema *searchOverrideSchema) (*searchOverride, error) {
+	override := &searchOverride{}
+	if err := c.request(ctx, http.MethodPut, overridePath(collectionName, name), nil, schema, override); err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (c *typesenseClient) retrieveOverride(ctx context.Context, collectionName, name string) (*searchOverride, error) {
+	override := &searchOverride{}
+	if err := c.request(ctx, http.MethodGet, overridePath(collectionName, name), nil, nil, override); err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (c *typesenseClient) deleteOverride(ctx context.Context, collectionName, name string) error {
+	return c.request(ctx, http.MethodDelete, overridePath(collectionName, name), nil, nil, nil)
+}
+
+func (c *typesenseClient) listOverrides(ctx context.Context, collectionName string) ([]searchOverride, error) {
+	res := &searchOverridesResponse{}
+	if err := c.request(ctx, http.MethodGet, "/collections/"+url.PathEscape(collectionName)+"/overrides", nil, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res.Overrides, nil
+}
+
+func overridePath(collectionName, name string) string {
+	return "/collections/" + url.PathEscape(collectionName) + "/overrides/" + url.PathEscape(name)
+}
+
+// importDocuments imports JSONL documents and returns the result of every line. A failed line
+// doesn't fail the request, so the results have to be checked by the caller.
+func (c *typesenseClient) importDocuments(ctx context.Context, collectionName string, params url.Values, jsonl []byte) ([]documentImportResult, error) {
+	b, err := c.send(ctx, http.MethodPost, documentsPath(collectionName)+"/import", params, "text/plain", bytes.NewReader(jsonl))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []documentImportResult{}
+	for _, line := range bytes.Split(bytes.TrimSpace(b), []byte("\n")) {
+		result := documentImportResult{}
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (c *typesenseClient) deleteDocumentsByFilter(ctx context.Context, collectionName, filterBy string, batchSize int) (int, error) {
+	params := url.Values{}
+	params.Set("filter_by", filterBy)
+	if batchSize > 0 {
+		params.Set("batch_size", strconv.Itoa(batchSize))
+	}
+
+	res := &documentsDeleteResponse{}
+	if err := c.request(ctx, http.MethodDelete, documentsPath(collectionName), params, nil, res); err != nil {
+		return 0, err
+	}
+
+	return res.NumDeleted, nil
+}
+
+func (c *typesenseClient) indexDocument(ctx context.Context, collectionName string, params url.Values, document map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.send(ctx, http.MethodPost, documentsPath(collectionName), params, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeDocument(res)
+}
+
+func (c *typesenseClient) retrieveDocument(ctx context.Context, collectionName, id string) (map[string]interface{}, error) {
+	res, err := c.send(ctx, http.MethodGet, documentsPath(collectionName)+"/"+url.PathEscape(id), nil, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeDocument(res)
+}
+
+func (c *typesenseClient) updateDocument(ctx context.Context, collectionName, id string, params url.Values, patch map[string]interface{}) error {
+	return c.request(ctx, http.MethodPatch, documentsPath(collectionName)+"/"+url.PathEscape(id), params, patch, nil)
+}
+
+func (c *typesenseClient) deleteDocument(ctx context.Context, collectionName, id string) error {
+	return c.request(ctx, http.MethodDelete, documentsPath(collectionName)+"/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// decodeDocument decodes numbers as json.Number so that large integers keep their precision.
+func decodeDocument(b []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	document := map[string]interface{}{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func (c *typesenseClient) search(ctx context.Context, collectionName string, params url.Values) (*searchResult, error) {
+	result := &searchResult{}
+	if err := c.request(ctx, http.MethodGet, documentsPath(collectionName)+"/search", params, nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *typesenseClient) multiSearch(ctx context.Context, params url.Values, request *multiSearchRequest) (*multiSearchResponse, error) {
+	response := &multiSearchResponse{}
+	if err := c.request(ctx, http.MethodPost, "/multi_search", params, request, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func documentsPath(collectionName string) string {
+	return "/collections/" + url.PathEscape(collectionName) + "/documents"
+}
@@ -0,0 +1,202 @@
+package typesense
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+// Same interval as the official Typesense clients use before retrying an unhealthy node.
+const defaultHealthcheckInterval = 60 * time.Second
+
+type clusterNode struct {
+	url *url.URL
+
+	healthy    bool
+	lastAccess time.Time
+}
+
+func newClusterNode(protocol, host string, port int, path string) (*clusterNode, error) {
+	if host == "" {
+		return nil, fmt.Errorf("host is required for a node")
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s://%s:%d%s", protocol, host, port, strings.TrimSuffix(path, "/")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &clusterNode{url: u, healthy: true}, nil
+}
+
+func newClusterNodeFromAddress(address string) (*clusterNode, error) {
+	u, err := url.Parse(strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid format, api_address should be <protocol>://<host>[:<port>][/<path>]: %s", address)
+	}
+
+	return &clusterNode{url: u, healthy: true}, nil
+}
+
+// clusterDoer sends requests to the nearest node when it is healthy and falls back to the other
+// nodes in a round-robin fashion. A node that fails is skipped until the healthcheck interval has
+// passed, so an apply still succeeds while one node of the cluster is down.
+type clusterDoer struct {
+	doer                api.HttpRequestDoer
+	nearestNode         *clusterNode
+	nodes               []*clusterNode
+	numRetries          int
+	retryInterval       time.Duration
+	healthcheckInterval time.Duration
+
+	mu          sync.Mutex
+	currentNode int
+}
+
+func newClusterDoer(doer api.HttpRequestDoer, nearestNode *clusterNode, nodes []*clusterNode, numRetries int, retryInterval time.Duration) *clusterDoer {
+	return &clusterDoer{
+		doer:                doer,
+		nearestNode:         nearestNode,
+		nodes:               nodes,
+		numRetries:          numRetries,
+		retryInterval:       retryInterval,
+		healthcheckInterval: defaultHealthcheckInterval,
+		// Start from the last node so that the first request goes to the first node.
+		currentNode: len(nodes) - 1,
+	}
+}
+
+// origin is the server URL given to the API client. Requests are rebased from it onto the node
+// picked for each attempt.
+func (c *clusterDoer) origin() *url.URL {
+	if c.nearestNode != nil {
+		return c.nearestNode.url
+	}
+
+	return c.nodes[0].url
+}
+
+func (c *clusterDoer) Do(req *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.numRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(c.retryInterval):
+			}
+		}
+
+		node := c.nextNode()
+
+		r, err := c.rebase(req, node, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.doer.Do(r)
+		if err != nil && req.Context().Err() != nil {
+			// The request was cancelled or timed out, which says nothing about the node.
+			return nil, req.Context().Err()
+		}
+
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			c.setHealthy(node, true)
+			return resp, nil
+		}
+
+		c.setHealthy(node, false)
+
+		if err != nil {
+			if !isRetryableRequestError(req, err) {
+				return nil, err
+			}
+
+			lastErr = err
+			continue
+		}
+
+		if attempt == c.numRetries || !isRetryableRequestStatus(req, resp.StatusCode) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		lastErr = fmt.Errorf("%s returned status %d", node.url.Host, resp.StatusCode)
+	}
+
+	return nil, lastErr
+}
+
+func (c *clusterDoer) rebase(req *http.Request, node *clusterNode, attempt int) (*http.Request, error) {
+	r := req.Clone(req.Context())
+
+	if attempt > 0 && req.Body != nil {
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("request to %s can't be retried on another node", req.URL.Path)
+		}
+
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		r.Body = body
+	}
+
+	origin := c.origin()
+
+	u := *req.URL
+	u.Scheme = node.url.Scheme
+	u.Host = node.url.Host
+	u.Path = node.url.Path + strings.TrimPrefix(req.URL.Path, origin.Path)
+	u.RawPath = ""
+
+	r.URL = &u
+	r.Host = ""
+	return r, nil
+}
+
+func (c *clusterDoer) nextNode() *clusterNode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nearestNode != nil && c.isDue(c.nearestNode) {
+		c.nearestNode.lastAccess = time.Now()
+		return c.nearestNode
+	}
+
+	var candidate *clusterNode
+	for range c.nodes {
+		c.currentNode = (c.currentNode + 1) % len(c.nodes)
+		candidate = c.nodes[c.currentNode]
+
+		if c.isDue(candidate) {
+			break
+		}
+	}
+
+	candidate.lastAccess = time.Now()
+	return candidate
+}
+
+func (c *clusterDoer) isDue(node *clusterNode) bool {
+	return node.healthy || time.Since(node.lastAccess) > c.healthcheckInterval
+}
+
+func (c *clusterDoer) setHealthy(node *clusterNode, healthy bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	node.healthy = healthy
+	node.lastAccess = time.Now()
+}
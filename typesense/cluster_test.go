@@ -0,0 +1,119 @@
+package typesense
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+)
+
+// fakeNodesDoer answers requests with the status of the node they are sent to, or fails to
+// connect to nodes without a status, and records the hosts in order.
+type fakeNodesDoer struct {
+	statuses map[string]int
+	hosts    []string
+}
+
+func (f *fakeNodesDoer) Do(req *http.Request) (*http.Response, error) {
+	f.hosts = append(f.hosts, req.URL.Host)
+
+	status, ok := f.statuses[req.URL.Host]
+	if !ok {
+		return nil, &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+	}
+
+	return &http.Response{StatusCode: status, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+}
+
+func newTestClusterDoer(t *testing.T, doer *fakeNodesDoer, nearestNode string, nodes ...string) *clusterDoer {
+	var nearest *clusterNode
+	if nearestNode != "" {
+		node, err := newClusterNodeFromAddress(nearestNode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		nearest = node
+	}
+
+	clusterNodes := make([]*clusterNode, len(nodes))
+	for i, address := range nodes {
+		node, err := newClusterNodeFromAddress(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clusterNodes[i] = node
+	}
+
+	return newClusterDoer(doer, nearest, clusterNodes, len(nodes), 0)
+}
+
+func TestClusterDoerFailsOverToHealthyNodes(t *testing.T) {
+	doer := &fakeNodesDoer{statuses: map[string]int{"node2:8108": http.StatusOK}}
+	cluster := newTestClusterDoer(t, doer, "", "http://node1:8108", "http://node2:8108")
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://node1:8108/collections", nil)
+		resp, err := cluster.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected 200, got %d", resp.StatusCode)
+		}
+	}
+
+	// The second request skips node1, which is unhealthy until the healthcheck interval passed.
+	expected := []string{"node1:8108", "node2:8108", "node2:8108"}
+	if len(doer.hosts) != len(expected) {
+		t.Fatalf("expected requests to %v, got %v", expected, doer.hosts)
+	}
+
+	for i, host := range expected {
+		if doer.hosts[i] != host {
+			t.Errorf("expected requests to %v, got %v", expected, doer.hosts)
+		}
+	}
+}
+
+func TestClusterDoerPrefersNearestNode(t *testing.T) {
+	doer := &fakeNodesDoer{statuses: map[string]int{"nearest:8108": http.StatusOK, "node1:8108": http.StatusOK}}
+	cluster := newTestClusterDoer(t, doer, "http://nearest:8108", "http://node1:8108")
+
+	req, _ := http.NewRequest(http.MethodGet, "http://nearest:8108/collections", nil)
+	if _, err := cluster.Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doer.hosts) != 1 || doer.hosts[0] != "nearest:8108" {
+		t.Errorf("expected a request to the nearest node, got %v", doer.hosts)
+	}
+}
+
+func TestClusterDoerDoesNotFailOverAppliedWrites(t *testing.T) {
+	cases := []struct {
+		status int
+		hosts  int
+	}{
+		{http.StatusInternalServerError, 1},
+		{http.StatusServiceUnavailable, 2},
+	}
+
+	for _, c := range cases {
+		doer := &fakeNodesDoer{statuses: map[string]int{"node1:8108": c.status, "node2:8108": http.StatusCreated}}
+		cluster := newTestClusterDoer(t, doer, "", "http://node1:8108", "http://node2:8108")
+
+		req, _ := http.NewRequest(http.MethodPost, "http://node1:8108/keys", bytes.NewReader([]byte(`{}`)))
+		if _, err := cluster.Do(req); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(doer.hosts) != c.hosts {
+			t.Errorf("status %d: expected requests to %d nodes, got %v", c.status, c.hosts, doer.hosts)
+		}
+	}
+}
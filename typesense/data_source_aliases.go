@@ -0,0 +1,67 @@
+package typesense
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTypesenseAliases() *schema.Resource {
+	return &schema.Resource{
+		Description: "Every collection alias of the server",
+		Schema: map[string]*schema.Schema{
+			"aliases": {
+				Type:        schema.TypeList,
+				Description: "Collection aliases sorted by name",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the collection alias",
+							Computed:    true,
+						},
+						"collection_name": {
+							Type:        schema.TypeString,
+							Description: "Name of the collection the alias points to",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceTypesenseAliasesRead,
+	}
+}
+
+func dataSourceTypesenseAliasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	aliases, err := client.withContext(ctx).Aliases().Retrieve()
+	if err != nil {
+		return errorDiagnostics(err)
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Name < aliases[j].Name
+	})
+
+	res := make([]interface{}, len(aliases))
+	for i, alias := range aliases {
+		res[i] = map[string]interface{}{
+			"name":            alias.Name,
+			"collection_name": alias.CollectionName,
+		}
+	}
+
+	if err := d.Set("aliases", res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("aliases")
+	return diags
+}
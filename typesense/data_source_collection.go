@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 )
 
 func dataSourceTypesenseCollection() *schema.Resource {
@@ -47,6 +46,113 @@ func dataSourceTypesenseCollection() *schema.Resource {
 							Computed:    true,
 							Description: "",
 						},
+						"sort": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Sortable field",
+						},
+						"infix": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enable infix search",
+						},
+						"locale": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Locale used to tokenize the field",
+						},
+						"stem": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enable stemming of the field values",
+						},
+						"store": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Store the field value on disk",
+						},
+						"range_index": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Optimize numeric range filters on the field",
+						},
+						"reference": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Field of another collection to join on",
+						},
+						"async_reference": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Allow documents to be indexed before the referenced document exists",
+						},
+						"num_dim": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of dimensions of a vector field",
+						},
+						"vec_dist": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Distance metric of a vector field",
+						},
+						"embed": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Embeddings generated from other fields",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"model_config": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"model_name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"url": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"indexing_prefix": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"query_prefix": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"hnsw_params": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "HNSW index parameters of a vector field",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ef_construction": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"m": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -54,6 +160,32 @@ func dataSourceTypesenseCollection() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"token_separators": {
+				Type:        schema.TypeList,
+				Description: "Characters used to split words in addition to the space and new-line characters",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"symbols_to_index": {
+				Type:        schema.TypeList,
+				Description: "Special characters that are indexed instead of being removed",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"enable_nested_fields": {
+				Type:        schema.TypeBool,
+				Description: "Index the fields of nested objects",
+				Computed:    true,
+			},
+			"metadata": {
+				Type:        schema.TypeString,
+				Description: "Free-form JSON object stored with the collection",
+				Computed:    true,
+			},
 			"num_documents": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -64,14 +196,14 @@ func dataSourceTypesenseCollection() *schema.Resource {
 }
 
 func dataSourceTypesenseCollectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
-	collection, err := client.Collection(d.Get("name").(string)).Retrieve()
+	collection, err := client.retrieveCollection(ctx, d.Get("name").(string))
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return errorDiagnostics(err)
 	}
 
 	log.Printf("[DEBUG] Got collection name:%s\n", collection.Name)
@@ -88,9 +220,14 @@ func dataSourceTypesenseCollectionRead(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
+	if err := flattenCollectionSettings(d, collection); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("num_documents", collection.NumDocuments); err != nil {
 		return diag.FromErr(err)
 	}
 
+	d.SetId(collection.Name)
 	return diags
 }
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 )
 
 func dataSourceTypesenseCollectionAlias() *schema.Resource {
@@ -28,16 +27,16 @@ func dataSourceTypesenseCollectionAlias() *schema.Resource {
 }
 
 func dataSourceTypesenseCollectionAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
 	id := d.Id()
 
-	alias, err := client.Alias(id).Retrieve()
+	alias, err := client.withContext(ctx).Alias(id).Retrieve()
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return errorDiagnostics(err)
 	}
 
 	if err := d.Set("name", alias.Name); err != nil {
@@ -0,0 +1,118 @@
+package typesense
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTypesenseCollections() *schema.Resource {
+	return &schema.Resource{
+		Description: "Every collection of the server, optionally filtered by name",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "Regular expression the collection names must match",
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "Names of the collections sorted by name",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"collections": {
+				Type:        schema.TypeList,
+				Description: "Collections sorted by name",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the collection",
+							Computed:    true,
+						},
+						"default_sorting_field": {
+							Type:        schema.TypeString,
+							Description: "Default sorting field of the collection",
+							Computed:    true,
+						},
+						"num_documents": {
+							Type:        schema.TypeInt,
+							Description: "Number of documents in the collection",
+							Computed:    true,
+						},
+						"created_at": {
+							Type:        schema.TypeInt,
+							Description: "Unix timestamp when the collection was created",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceTypesenseCollectionsRead,
+	}
+}
+
+func dataSourceTypesenseCollectionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		nameRegex = re
+	}
+
+	collections, err := client.listCollections(ctx)
+	if err != nil {
+		return errorDiagnostics(err)
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+
+	names := []string{}
+	res := []interface{}{}
+	for _, collection := range collections {
+		if nameRegex != nil && !nameRegex.MatchString(collection.Name) {
+			continue
+		}
+
+		names = append(names, collection.Name)
+		res = append(res, map[string]interface{}{
+			"name":                  collection.Name,
+			"default_sorting_field": stringValue(collection.DefaultSortingField),
+			"num_documents":         int(collection.NumDocuments),
+			"created_at":            int(collection.CreatedAt),
+		})
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("collections", res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("collections")
+	if nameRegex != nil {
+		d.SetId("collections:" + nameRegex.String())
+	}
+	return diags
+}
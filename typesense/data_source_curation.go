@@ -3,10 +3,10 @@ package typesense
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 )
 
 func dataSourceTypesenseCuration() *schema.Resource {
@@ -30,12 +30,27 @@ func dataSourceTypesenseCuration() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"query": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:        schema.TypeString,
+							Description: "Query to match",
+							Computed:    true,
 						},
 						"match": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:        schema.TypeString,
+							Description: "Whether the query should match `exact`ly or only be `contains`ed in the search query",
+							Computed:    true,
+						},
+						"filter_by": {
+							Type:        schema.TypeString,
+							Description: "Filter the search request must contain for the curation to apply",
+							Computed:    true,
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Description: "Tags that trigger the curation when they are passed with the `override_tags` search parameter",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
 						},
 					},
 				},
@@ -73,13 +88,73 @@ func dataSourceTypesenseCuration() *schema.Resource {
 					},
 				},
 			},
+			"filter_by": {
+				Type:        schema.TypeString,
+				Description: "Filter applied to the search results when the curation matches",
+				Computed:    true,
+			},
+			"sort_by": {
+				Type:        schema.TypeString,
+				Description: "Sort order applied to the search results when the curation matches",
+				Computed:    true,
+			},
+			"replace_query": {
+				Type:        schema.TypeString,
+				Description: "Query that replaces the search query when the curation matches",
+				Computed:    true,
+			},
+			"remove_matched_tokens": {
+				Type:        schema.TypeBool,
+				Description: "Whether the tokens matching the rule are removed from the search query",
+				Computed:    true,
+			},
+			"filter_curated_hits": {
+				Type:        schema.TypeBool,
+				Description: "Whether the filters of the search request are also applied to the included documents",
+				Computed:    true,
+			},
+			"effective_from_ts": {
+				Type:        schema.TypeInt,
+				Description: "Unix timestamp from which the curation is in effect",
+				Computed:    true,
+			},
+			"effective_to_ts": {
+				Type:        schema.TypeInt,
+				Description: "Unix timestamp until which the curation is in effect",
+				Computed:    true,
+			},
+			"effective_from": {
+				Type:        schema.TypeString,
+				Description: "RFC3339 time from which the curation is in effect",
+				Computed:    true,
+			},
+			"effective_to": {
+				Type:        schema.TypeString,
+				Description: "RFC3339 time until which the curation is in effect",
+				Computed:    true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the curation is in effect at the time it is read",
+				Computed:    true,
+			},
+			"stop_processing": {
+				Type:        schema.TypeBool,
+				Description: "Whether the remaining curations are skipped when this curation matches",
+				Computed:    true,
+			},
+			"metadata": {
+				Type:        schema.TypeString,
+				Description: "Free-form JSON object returned with the search results when the curation matches",
+				Computed:    true,
+			},
 		},
 		ReadContext: dataSourceTypesenseCurationRead,
 	}
 }
 
 func dataSourceTypesenseCurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -87,10 +162,10 @@ func dataSourceTypesenseCurationRead(ctx context.Context, d *schema.ResourceData
 	collectionName := d.Get("collection_name").(string)
 	id := fmt.Sprintf("%s.%s", collectionName, name)
 
-	override, err := client.Collection(collectionName).Override(name).Retrieve()
+	override, err := client.retrieveOverride(ctx, collectionName, name)
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return errorDiagnostics(err)
 	}
 
 	if err := d.Set("name", override.Id); err != nil {
@@ -117,6 +192,43 @@ func dataSourceTypesenseCurationRead(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	if err := flattenCurationSettings(d, override); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var from, to int
+	if override.EffectiveFromTs != nil {
+		from = int(*override.EffectiveFromTs)
+	}
+
+	if override.EffectiveToTs != nil {
+		to = int(*override.EffectiveToTs)
+	}
+
+	if err := d.Set("effective_from_ts", from); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("effective_to_ts", to); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("effective_from", formatUnixTime(override.EffectiveFromTs)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("effective_to", formatUnixTime(override.EffectiveToTs)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now().Unix()
+	active := (override.EffectiveFromTs == nil || *override.EffectiveFromTs <= now) &&
+		(override.EffectiveToTs == nil || now <= *override.EffectiveToTs)
+
+	if err := d.Set("active", active); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(id)
 	return diags
 }
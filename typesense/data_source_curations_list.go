@@ -0,0 +1,150 @@
+package typesense
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTypesenseCurationsList() *schema.Resource {
+	return &schema.Resource{
+		Description: "Every curation of a collection",
+		Schema: map[string]*schema.Schema{
+			"collection_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the collection",
+				Required:    true,
+			},
+			"curations": {
+				Type:        schema.TypeList,
+				Description: "Curations sorted by name",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the curation",
+							Computed:    true,
+						},
+						"rule": {
+							Type:        schema.TypeList,
+							Description: "Rule of this curation",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"query": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"match": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"filter_by": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"tags": {
+										Type:     schema.TypeSet,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"includes": {
+							Type:        schema.TypeList,
+							Description: "Documents to include",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"position": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"excludes": {
+							Type:        schema.TypeList,
+							Description: "Documents to exclude",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"effective_from_ts": {
+							Type:        schema.TypeInt,
+							Description: "Unix timestamp from which the curation is in effect",
+							Computed:    true,
+						},
+						"effective_to_ts": {
+							Type:        schema.TypeInt,
+							Description: "Unix timestamp until which the curation is in effect",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceTypesenseCurationsListRead,
+	}
+}
+
+func dataSourceTypesenseCurationsListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	collectionName := d.Get("collection_name").(string)
+
+	overrides, err := client.listOverrides(ctx, collectionName)
+	if err != nil {
+		return errorDiagnostics(err)
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Id < overrides[j].Id
+	})
+
+	res := make([]interface{}, len(overrides))
+	for i, override := range overrides {
+		var from, to int
+		if override.EffectiveFromTs != nil {
+			from = int(*override.EffectiveFromTs)
+		}
+
+		if override.EffectiveToTs != nil {
+			to = int(*override.EffectiveToTs)
+		}
+
+		res[i] = map[string]interface{}{
+			"name":              override.Id,
+			"rule":              flattenCurationRule(override.Rule),
+			"includes":          flattenCurationIncludes(override.Includes),
+			"excludes":          flattenCurationExcludes(override.Excludes),
+			"effective_from_ts": from,
+			"effective_to_ts":   to,
+		}
+	}
+
+	if err := d.Set("curations", res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(collectionName)
+	return diags
+}
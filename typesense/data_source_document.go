@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 )
 
 func dataSourceTypesenseDocument() *schema.Resource {
@@ -26,13 +25,18 @@ func dataSourceTypesenseDocument() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"document_json": {
+				Type:        schema.TypeString,
+				Description: "Document's body as a JSON object, for use with `jsondecode`",
+				Computed:    true,
+			},
 		},
 		ReadContext: dataSourceTypesenseDocumentRead,
 	}
 }
 
 func dataSourceTypesenseDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -49,13 +53,22 @@ func dataSourceTypesenseDocumentRead(ctx context.Context, d *schema.ResourceData
 
 	id := fmt.Sprintf("%s.%s", collectionName, docId)
 
-	doc, err := client.Collection(collectionName).Document(docId).Retrieve()
+	doc, err := client.retrieveDocument(ctx, collectionName, docId)
 	if err != nil {
 		d.SetId("")
+		return errorDiagnostics(err)
+	}
+
+	if err := d.Set("document", flattenDocumentStrings(doc)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	documentJSON, err := flattenJSON(doc)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("document", doc); err != nil {
+	if err := d.Set("document_json", documentJSON); err != nil {
 		return diag.FromErr(err)
 	}
 
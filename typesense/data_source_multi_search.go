@@ -0,0 +1,223 @@
+package typesense
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTypesenseMultiSearch() *schema.Resource {
+	searchSchema := multiSearchParamsSchema()
+	searchSchema["collection_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Name of the collection",
+		Required:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Results of several searches sent in a single request. Failed searches are reported as warnings",
+		Schema: map[string]*schema.Schema{
+			"searches": {
+				Type:        schema.TypeList,
+				Description: "Searches to run. Their parameters take precedence over the common parameters",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: searchSchema,
+				},
+			},
+			"common_params": {
+				Type:        schema.TypeList,
+				Description: "Parameters shared by every search",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: multiSearchParamsSchema(),
+				},
+			},
+			"union": {
+				Type:        schema.TypeBool,
+				Description: "Whether the hits of every search are merged into a single result",
+				Optional:    true,
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Description: "Result of each search in the order of `searches`, or the single merged result with `union`",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"found": {
+							Type:        schema.TypeInt,
+							Description: "Number of documents matching the search",
+							Computed:    true,
+						},
+						"document_ids": {
+							Type:        schema.TypeList,
+							Description: "Ids of the documents of the requested page in ranking order",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"hits": {
+							Type:        schema.TypeString,
+							Description: "Hits of the requested page as a JSON array, for use with `jsondecode`",
+							Computed:    true,
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Description: "Error of the search when it failed",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceTypesenseMultiSearchRead,
+	}
+}
+
+func multiSearchParamsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"q": {
+			Type:        schema.TypeString,
+			Description: "Search query, or `*` to match every document",
+			Optional:    true,
+		},
+		"query_by": {
+			Type:        schema.TypeString,
+			Description: "Fields to query",
+			Optional:    true,
+		},
+		"filter_by": {
+			Type:        schema.TypeString,
+			Description: "Filter applied to the results",
+			Optional:    true,
+		},
+		"sort_by": {
+			Type:        schema.TypeString,
+			Description: "Sort order of the results",
+			Optional:    true,
+		},
+		"per_page": {
+			Type:         schema.TypeInt,
+			Description:  "Number of hits per page",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"page": {
+			Type:         schema.TypeInt,
+			Description:  "Page of the results",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"preset": {
+			Type:        schema.TypeString,
+			Description: "Name of a preset whose parameters are used for the search",
+			Optional:    true,
+		},
+	}
+}
+
+func dataSourceTypesenseMultiSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	request := &multiSearchRequest{}
+	for _, v := range d.Get("searches").([]interface{}) {
+		search := expandMultiSearchParams(v.(map[string]interface{}))
+		if collectionName := v.(map[string]interface{})["collection_name"].(string); collectionName != "" {
+			search["collection"] = collectionName
+		}
+
+		request.Searches = append(request.Searches, search)
+	}
+
+	if d.Get("union").(bool) {
+		request.Union = boolPointer(true)
+	}
+
+	common := url.Values{}
+	if vs := d.Get("common_params").([]interface{}); len(vs) > 0 && vs[0] != nil {
+		for k, v := range expandMultiSearchParams(vs[0].(map[string]interface{})) {
+			common.Set(k, fmt.Sprint(v))
+		}
+	}
+
+	response, err := client.multiSearch(ctx, common, request)
+	if err != nil {
+		return errorDiagnostics(err)
+	}
+
+	// A union search returns a single result instead of one result per search.
+	results := response.Results
+	if request.Union != nil {
+		results = []multiSearchResult{{searchResult: response.searchResult}}
+	}
+
+	res := make([]interface{}, len(results))
+	for i, result := range results {
+		if result.Error != "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Search %d failed", i+1),
+				Detail:   result.Error,
+			})
+		}
+
+		hits := "[]"
+		if len(result.Hits) > 0 {
+			hits, err = flattenJSON(result.Hits)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		res[i] = map[string]interface{}{
+			"found":        result.Found,
+			"document_ids": result.documentIds(),
+			"hits":         hits,
+			"error":        result.Error,
+		}
+	}
+
+	if err := d.Set("results", res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	b, err := json.Marshal(request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sum := sha256.Sum256(append(b, common.Encode()...))
+	d.SetId(hex.EncodeToString(sum[:8]))
+	return diags
+}
+
+// expandMultiSearchParams returns the search parameters that are set.
+func expandMultiSearchParams(v map[string]interface{}) map[string]interface{} {
+	params := map[string]interface{}{}
+
+	for _, key := range []string{"q", "query_by", "filter_by", "sort_by", "preset"} {
+		if s, ok := v[key].(string); ok && s != "" {
+			params[key] = s
+		}
+	}
+
+	for _, key := range []string{"per_page", "page"} {
+		if n, ok := v[key].(int); ok && n > 0 {
+			params[key] = n
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,55 @@
+package typesense
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTypesensePreset() *schema.Resource {
+	return &schema.Resource{
+		Description: "Set of search parameters stored on the server that can be referenced with the `preset` search parameter",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the preset",
+				Required:    true,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "Search parameters as a JSON object, or a multi-search request with a `searches` list",
+				Computed:    true,
+			},
+		},
+		ReadContext: dataSourceTypesensePresetRead,
+	}
+}
+
+func dataSourceTypesensePresetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	p, err := client.retrievePreset(ctx, d.Get("name").(string))
+	if err != nil {
+		d.SetId("")
+		return errorDiagnostics(err)
+	}
+
+	if err := d.Set("name", p.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	value, err := flattenJSON(p.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("value", value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(p.Name)
+	return diags
+}
@@ -0,0 +1,22 @@
+package typesense
+
+import "testing"
+
+func TestGenerateScopedSearchKey(t *testing.T) {
+	// Example of https://typesense.org/docs/latest/api/api-keys.html#generate-scoped-search-key
+	key, err := generateScopedSearchKey("RN23GFr1s6jQ9kgSNg2O7fYcAUXU7127", []byte(`{"filter_by":"company_id:124","expires_at":1906054106}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "OW9DYWZGS1Q1RGdSbmo0S1QrOWxhbk9PL2kxbTU1eXA3bCthdmE5eXJKRT1STjIzeyJmaWx0ZXJfYnkiOiJjb21wYW55X2lkOjEyNCIsImV4cGlyZXNfYXQiOjE5MDYwNTQxMDZ9"
+	if key != expected {
+		t.Errorf("expected %s, got %s", expected, key)
+	}
+}
+
+func TestGenerateScopedSearchKeyShortParentKey(t *testing.T) {
+	if _, err := generateScopedSearchKey("RN2", []byte(`{}`)); err == nil {
+		t.Error("expected an error for a parent key shorter than its prefix")
+	}
+}
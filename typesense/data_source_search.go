@@ -0,0 +1,135 @@
+package typesense
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTypesenseSearch() *schema.Resource {
+	return &schema.Resource{
+		Description: "Results of a search, for example to check the effect of curations and synonyms with postconditions",
+		Schema: map[string]*schema.Schema{
+			"collection_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the collection",
+				Required:    true,
+			},
+			"q": {
+				Type:        schema.TypeString,
+				Description: "Search query, or `*` to match every document",
+				Required:    true,
+			},
+			"query_by": {
+				Type:        schema.TypeString,
+				Description: "Fields to query. Required unless it is set by the preset",
+				Optional:    true,
+			},
+			"filter_by": {
+				Type:        schema.TypeString,
+				Description: "Filter applied to the results",
+				Optional:    true,
+			},
+			"sort_by": {
+				Type:        schema.TypeString,
+				Description: "Sort order of the results",
+				Optional:    true,
+			},
+			"per_page": {
+				Type:         schema.TypeInt,
+				Description:  "Number of hits per page",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"page": {
+				Type:         schema.TypeInt,
+				Description:  "Page of the results",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"preset": {
+				Type:        schema.TypeString,
+				Description: "Name of a preset whose parameters are used for the search",
+				Optional:    true,
+			},
+			"found": {
+				Type:        schema.TypeInt,
+				Description: "Number of documents matching the search",
+				Computed:    true,
+			},
+			"hits": {
+				Type:        schema.TypeString,
+				Description: "Hits of the requested page as a JSON array, for use with `jsondecode`",
+				Computed:    true,
+			},
+			"document_ids": {
+				Type:        schema.TypeList,
+				Description: "Ids of the documents of the requested page in ranking order",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext: dataSourceTypesenseSearchRead,
+	}
+}
+
+func dataSourceTypesenseSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	collectionName := d.Get("collection_name").(string)
+
+	params := url.Values{}
+	params.Set("q", d.Get("q").(string))
+	for _, key := range []string{"query_by", "filter_by", "sort_by", "preset"} {
+		if v := d.Get(key).(string); v != "" {
+			params.Set(key, v)
+		}
+	}
+
+	for _, key := range []string{"per_page", "page"} {
+		if v := d.Get(key).(int); v > 0 {
+			params.Set(key, strconv.Itoa(v))
+		}
+	}
+
+	result, err := client.search(ctx, collectionName, params)
+	if err != nil {
+		return errorDiagnostics(err)
+	}
+
+	if err := d.Set("found", result.Found); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hits, err := flattenJSON(result.Hits)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if hits == "" {
+		hits = "[]"
+	}
+
+	if err := d.Set("hits", hits); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("document_ids", result.documentIds()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	sum := sha256.Sum256([]byte(params.Encode()))
+	d.SetId(fmt.Sprintf("%s.%s", collectionName, hex.EncodeToString(sum[:8])))
+	return diags
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 )
 
 func dataSourceTypesenseSynonyms() *schema.Resource {
@@ -43,7 +42,7 @@ func dataSourceTypesenseSynonyms() *schema.Resource {
 }
 
 func dataSourceTypesenseSynonymsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -52,10 +51,10 @@ func dataSourceTypesenseSynonymsRead(ctx context.Context, d *schema.ResourceData
 
 	id := fmt.Sprintf("%s.%s", collectionName, name)
 
-	synonym, err := client.Collection(collectionName).Synonym(name).Retrieve()
+	synonym, err := client.withContext(ctx).Collection(collectionName).Synonym(name).Retrieve()
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return errorDiagnostics(err)
 	}
 
 	if err := d.Set("collection_name", collectionName); err != nil {
@@ -72,12 +71,8 @@ func dataSourceTypesenseSynonymsRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	if synonym.Root != "" {
-		if err := d.Set("root", synonym.Root); err != nil {
-			if err := d.Set("root", synonym.Root); err != nil {
-				return diag.FromErr(err)
-			}
-		}
+	if err := d.Set("root", stringValue(synonym.Root)); err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(id)
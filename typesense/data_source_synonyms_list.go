@@ -0,0 +1,83 @@
+package typesense
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTypesenseSynonymsList() *schema.Resource {
+	return &schema.Resource{
+		Description: "Every synonyms of a collection",
+		Schema: map[string]*schema.Schema{
+			"collection_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the collection",
+				Required:    true,
+			},
+			"synonyms": {
+				Type:        schema.TypeList,
+				Description: "Synonyms sorted by name",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the synonyms",
+							Computed:    true,
+						},
+						"root": {
+							Type:        schema.TypeString,
+							Description: "Root for one-way synonym",
+							Computed:    true,
+						},
+						"synonyms": {
+							Type:        schema.TypeList,
+							Description: "Words that are considered equivalent",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceTypesenseSynonymsListRead,
+	}
+}
+
+func dataSourceTypesenseSynonymsListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	collectionName := d.Get("collection_name").(string)
+
+	synonyms, err := client.withContext(ctx).Collection(collectionName).Synonyms().Retrieve()
+	if err != nil {
+		return errorDiagnostics(err)
+	}
+
+	sort.Slice(synonyms, func(i, j int) bool {
+		return synonyms[i].Id < synonyms[j].Id
+	})
+
+	res := make([]interface{}, len(synonyms))
+	for i, synonym := range synonyms {
+		res[i] = map[string]interface{}{
+			"name":     synonym.Id,
+			"root":     stringValue(synonym.Root),
+			"synonyms": synonym.Synonyms,
+		}
+	}
+
+	if err := d.Set("synonyms", res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(collectionName)
+	return diags
+}
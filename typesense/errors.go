@@ -0,0 +1,61 @@
+package typesense
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/typesense/typesense-go/typesense"
+)
+
+func httpErrorStatus(err error) (int, bool) {
+	var httpErr *typesense.HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0, false
+	}
+
+	return httpErr.Status, true
+}
+
+func isNotFoundError(err error) bool {
+	status, ok := httpErrorStatus(err)
+	return ok && status == http.StatusNotFound
+}
+
+func isAuthError(err error) bool {
+	status, ok := httpErrorStatus(err)
+	return ok && (status == http.StatusUnauthorized || status == http.StatusForbidden)
+}
+
+// readDiagnostics handles an error of a Read function. Only an object that doesn't exist anymore
+// is removed from the state. Other errors keep it, so that an unavailable server doesn't cause the
+// object to be created again by the next apply.
+func readDiagnostics(d *schema.ResourceData, err error) diag.Diagnostics {
+	if isNotFoundError(err) {
+		log.Printf("[WARN] %s doesn't exist anymore, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return errorDiagnostics(err)
+}
+
+// errorDiagnostics converts an error to diagnostics, explaining what to check when the server
+// rejects the API key.
+func errorDiagnostics(err error) diag.Diagnostics {
+	if isAuthError(err) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Typesense rejected the API key",
+				Detail: fmt.Sprintf("%s\n\nCheck that api_key or the TYPESENSE_API_KEY environment variable is set to a valid key, "+
+					"and that the key allows the actions and collections used by this configuration.", err),
+			},
+		}
+	}
+
+	return diag.FromErr(err)
+}
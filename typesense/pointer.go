@@ -3,3 +3,43 @@ package typesense
 func boolPointer(i bool) *bool {
 	return &i
 }
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func nonEmptyStringPointer(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func boolValue(p *bool) bool {
+	if p == nil {
+		return false
+	}
+
+	return *p
+}
+
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+
+	return *p
+}
+
+func intValue(p *int) int {
+	if p == nil {
+		return 0
+	}
+
+	return *p
+}
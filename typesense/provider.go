@@ -2,14 +2,16 @@ package typesense
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/typesense/typesense-go/typesense/api"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
@@ -20,41 +22,306 @@ func Provider() *schema.Provider {
 			},
 			"api_address": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("TYPESENSE_API_ADDRESS", nil),
-				Description: "URL of the Typesense server. This can also be set via the `TYPESENSE_API_ADDRESS` environment variable.",
+				Description: "URL of the Typesense server. This can also be set via the `TYPESENSE_API_ADDRESS` environment variable. It is ignored when `nodes` are set.",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Nodes of a Typesense cluster. Requests are sent to the nodes in a round-robin fashion and unhealthy nodes are skipped.",
+				Elem:        providerNodeSchema(),
+			},
+			"nearest_node": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Node that is tried first for every request, such as a load-balanced endpoint. The `nodes` are used when it is unhealthy.",
+				Elem:        providerNodeSchema(),
+			},
+			"connection_timeout_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				Description:  "Timeout of connecting to a node. Requests themselves are limited by the timeouts of the resources.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"num_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				Description:  "Number of times a failed request is retried on the next node. Requests other than reads are only retried when the connection couldn't be established or the node responded with 503.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_interval_seconds": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0.1,
+				Description:  "Time to wait before retrying a failed request.",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Backoff of requests that fail with a retryable status code or a network error, after they have been retried on every node. Requests other than reads are only sent again when the connection couldn't be established or the server responded with 429 or 503, since they may have been applied otherwise.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultRetryMaxAttempts,
+							Description:  "Number of times a request is sent before it fails",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"min_backoff_seconds": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      defaultRetryMinBackoff.Seconds(),
+							Description:  "Time to wait before the first retry. It doubles for every further retry",
+							ValidateFunc: validation.FloatAtLeast(0),
+						},
+						"max_backoff_seconds": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      defaultRetryMaxBackoff.Seconds(),
+							Description:  "Maximum time to wait between retries",
+							ValidateFunc: validation.FloatAtLeast(0),
+						},
+						"retryable_status_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Status codes that are retried. Defaults to 429, 500, 502, 503 and 504. Requests other than reads are only retried on 429 and 503",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(400, 599),
+							},
+						},
+					},
+				},
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Maximum number of requests sent per second, counting every retry and failover to another node. Requests aren't limited by default.",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TYPESENSE_CA_CERT_PEM", nil),
+				Description: "PEM-encoded CA certificates that verify the server certificate instead of the system ones. This can also be set via the `TYPESENSE_CA_CERT_PEM` environment variable.",
+			},
+			"client_cert_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "PEM-encoded client certificate for mutual TLS.",
+				RequiredWith: []string{"client_key_pem"},
+			},
+			"client_key_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "PEM-encoded private key of the client certificate.",
+				RequiredWith: []string{"client_cert_pem"},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the server certificate is trusted without verifying it. Only use it for testing.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the proxy requests are sent through. By default the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether resources are only read. Creating, updating or deleting a resource fails before any request is sent, so that plans can be run with a search-only key.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Headers sent with every request, such as the credentials of an API gateway.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"typesense_collection":       dataSourceTypesenseCollection(),
-			"typesense_collection_alias": dataSourceTypesenseCollectionAlias(),
-			"typesense_curation":         dataSourceTypesenseCuration(),
-			"typesense_document":         dataSourceTypesenseDocument(),
-			"typesense_synonyms":         dataSourceTypesenseSynonyms(),
+			"typesense_aliases":           dataSourceTypesenseAliases(),
+			"typesense_collection":        dataSourceTypesenseCollection(),
+			"typesense_collection_alias":  dataSourceTypesenseCollectionAlias(),
+			"typesense_collections":       dataSourceTypesenseCollections(),
+			"typesense_curation":          dataSourceTypesenseCuration(),
+			"typesense_curations_list":    dataSourceTypesenseCurationsList(),
+			"typesense_document":          dataSourceTypesenseDocument(),
+			"typesense_multi_search":      dataSourceTypesenseMultiSearch(),
+			"typesense_preset":            dataSourceTypesensePreset(),
+			"typesense_scoped_search_key": dataSourceTypesenseScopedSearchKey(),
+			"typesense_search":            dataSourceTypesenseSearch(),
+			"typesense_synonyms":          dataSourceTypesenseSynonyms(),
+			"typesense_synonyms_list":     dataSourceTypesenseSynonymsList(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"typesense_collection":       resourceTypesenseCollection(),
-			"typesense_collection_alias": resourceTypesenseCollectionAlias(),
-			"typesense_document":         resourceTypesenseDocument(),
-			"typesense_curation":         resourceTypesenseCuration(),
-			"typesense_synonyms":         resourceTypesenseSynonyms(),
+			"typesense_analytics_rule":             resourceTypesenseAnalyticsRule(),
+			"typesense_api_key":                    resourceTypesenseAPIKey(),
+			"typesense_collection":                 resourceTypesenseCollection(),
+			"typesense_collection_alias":           resourceTypesenseCollectionAlias(),
+			"typesense_collection_rollout":         resourceTypesenseCollectionRollout(),
+			"typesense_document":                   resourceTypesenseDocument(),
+			"typesense_documents_delete_by_filter": resourceTypesenseDocumentsDeleteByFilter(),
+			"typesense_documents_import":           resourceTypesenseDocumentsImport(),
+			"typesense_curation":                   resourceTypesenseCuration(),
+			"typesense_preset":                     resourceTypesensePreset(),
+			"typesense_stopwords":                  resourceTypesenseStopwords(),
+			"typesense_synonyms":                   resourceTypesenseSynonyms(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, r := range p.ResourcesMap {
+		guardResource(name, r)
+	}
+
+	return p
+}
+
+func providerNodeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "https",
+				Description:  "Protocol of the node",
+				ValidateFunc: validation.StringInSlice([]string{"http", "https"}, false),
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Host of the node",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      443,
+				Description:  "Port of the node",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path prefix when the node is served behind a reverse proxy",
+			},
+		},
+	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	opts := []typesense.ClientOption{}
+	nodes := []*clusterNode{}
+	for _, v := range d.Get("nodes").([]interface{}) {
+		node, err := expandClusterNode(v.(map[string]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	if v := d.Get("api_address").(string); v != "" && len(nodes) == 0 {
+		node, err := newClusterNodeFromAddress(v)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-	if v := d.Get("api_key").(string); v != "" {
-		opts = append(opts, typesense.WithAPIKey(v))
+		nodes = append(nodes, node)
 	}
 
-	if v := d.Get("api_address").(string); v != "" {
-		opts = append(opts, typesense.WithServer(v))
+	if len(nodes) == 0 {
+		return nil, diag.Errorf("either api_address or nodes must be set")
 	}
 
-	return typesense.NewClient(opts...), nil
+	var nearestNode *clusterNode
+	if vs := d.Get("nearest_node").([]interface{}); len(vs) > 0 {
+		node, err := expandClusterNode(vs[0].(map[string]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		nearestNode = node
+	}
+
+	httpClient, err := expandHTTPClient(d, time.Duration(d.Get("connection_timeout_seconds").(int))*time.Second)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// The rate limit applies to every attempt, including those that fail over to another node.
+	var nodeDoer api.HttpRequestDoer = httpClient
+	if v := d.Get("max_requests_per_second").(float64); v > 0 {
+		nodeDoer = newRateLimitDoer(nodeDoer, v)
+	}
+
+	cluster := newClusterDoer(
+		nodeDoer,
+		nearestNode,
+		nodes,
+		d.Get("num_retries").(int),
+		time.Duration(d.Get("retry_interval_seconds").(float64)*float64(time.Second)),
+	)
+
+	doer := expandRetryDoer(cluster, d.Get("retry").([]interface{}))
+
+	client, err := newTypesenseClient(cluster.origin().String(), d.Get("api_key").(string), doer)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client.readOnly = d.Get("read_only").(bool)
+
+	// The key is only checked within the connection timeout, so that an unavailable server doesn't
+	// delay plans.
+	lookupCtx, cancel := context.WithTimeout(ctx, time.Duration(d.Get("connection_timeout_seconds").(int))*time.Second)
+	defer cancel()
+
+	keyActions, diags := lookupKeyActions(lookupCtx, client)
+	client.keyActions = keyActions
+
+	return client, diags
+}
+
+func expandRetryDoer(doer api.HttpRequestDoer, vs []interface{}) *retryDoer {
+	if len(vs) == 0 || vs[0] == nil {
+		return newRetryDoer(doer, defaultRetryMaxAttempts, defaultRetryMinBackoff, defaultRetryMaxBackoff, defaultRetryableStatusCodes)
+	}
+
+	v := vs[0].(map[string]interface{})
+
+	codes := defaultRetryableStatusCodes
+	if set := v["retryable_status_codes"].(*schema.Set); set.Len() > 0 {
+		codes = []int{}
+		for _, code := range set.List() {
+			codes = append(codes, code.(int))
+		}
+	}
+
+	return newRetryDoer(
+		doer,
+		v["max_attempts"].(int),
+		time.Duration(v["min_backoff_seconds"].(float64)*float64(time.Second)),
+		time.Duration(v["max_backoff_seconds"].(float64)*float64(time.Second)),
+		codes,
+	)
+}
+
+func expandClusterNode(v map[string]interface{}) (*clusterNode, error) {
+	return newClusterNode(v["protocol"].(string), v["host"].(string), v["port"].(int), v["path"].(string))
 }
@@ -0,0 +1,48 @@
+package typesense
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+// rateLimitDoer spaces requests evenly so that no more than the configured number of requests is
+// sent per second, even when Terraform applies many resources in parallel.
+type rateLimitDoer struct {
+	doer     api.HttpRequestDoer
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimitDoer(doer api.HttpRequestDoer, requestsPerSecond float64) *rateLimitDoer {
+	return &rateLimitDoer{
+		doer:     doer,
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+	}
+}
+
+func (r *rateLimitDoer) Do(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if wait > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return r.doer.Do(req)
+}
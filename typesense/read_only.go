@@ -0,0 +1,212 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceActions are the API key actions each resource needs to read and to change its objects.
+var resourceActions = map[string]struct {
+	read  []string
+	write []string
+}{
+	"typesense_analytics_rule": {
+		read:  []string{"analytics/rules:get"},
+		write: []string{"analytics/rules:upsert", "analytics/rules:delete"},
+	},
+	"typesense_api_key": {
+		read:  []string{"keys:get"},
+		write: []string{"keys:create", "keys:delete"},
+	},
+	"typesense_collection": {
+		read:  []string{"collections:get"},
+		write: []string{"collections:create", "collections:update", "collections:delete"},
+	},
+	"typesense_collection_alias": {
+		read:  []string{"aliases:get"},
+		write: []string{"aliases:upsert", "aliases:delete"},
+	},
+	"typesense_collection_rollout": {
+		read:  []string{"aliases:get", "collections:get"},
+		write: []string{"aliases:upsert", "aliases:delete", "collections:create", "collections:delete", "documents:import", "documents:search"},
+	},
+	"typesense_curation": {
+		read:  []string{"overrides:get"},
+		write: []string{"overrides:upsert", "overrides:delete"},
+	},
+	"typesense_document": {
+		read:  []string{"documents:get"},
+		write: []string{"documents:create", "documents:update", "documents:delete"},
+	},
+	"typesense_documents_delete_by_filter": {
+		write: []string{"documents:delete"},
+	},
+	"typesense_documents_import": {
+		read:  []string{"collections:get"},
+		write: []string{"documents:import", "documents:delete"},
+	},
+	"typesense_preset": {
+		read:  []string{"presets:get"},
+		write: []string{"presets:upsert", "presets:delete"},
+	},
+	"typesense_stopwords": {
+		read:  []string{"stopwords:get"},
+		write: []string{"stopwords:upsert", "stopwords:delete"},
+	},
+	"typesense_synonyms": {
+		read:  []string{"synonyms:get"},
+		write: []string{"synonyms:upsert", "synonyms:delete"},
+	},
+}
+
+// guardResource wraps the functions of a resource so that creating, updating and deleting it fails
+// before any request is sent when the provider is read-only. They also warn once per resource
+// type when the API key lacks the actions the resource needs.
+func guardResource(name string, r *schema.Resource) {
+	if read := r.ReadContext; read != nil {
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := meta.(*typesenseClient).missingActionsDiagnostics(name)
+			return append(diags, read(ctx, d, meta)...)
+		}
+	}
+
+	r.CreateContext = guardResourceWrite(name, "created", r.CreateContext)
+	r.UpdateContext = guardResourceWrite(name, "updated", r.UpdateContext)
+	r.DeleteContext = guardResourceWrite(name, "deleted", r.DeleteContext)
+}
+
+func guardResourceWrite(name, verb string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*typesenseClient)
+
+		if client.readOnly {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "The provider is read-only",
+				Detail:   fmt.Sprintf("%s can't be %s because read_only is set in the provider configuration.", name, verb),
+			}}
+		}
+
+		diags := client.missingActionsDiagnostics(name)
+		return append(diags, f(ctx, d, meta)...)
+	}
+}
+
+// lookupKeyActions looks up the actions of the configured API key by its prefix. Only keys that
+// are allowed to list keys can see their own actions, so it returns nil for other keys, as well as
+// for keys whose actions aren't restricted.
+func lookupKeyActions(ctx context.Context, client *typesenseClient) ([]string, diag.Diagnostics) {
+	keys, err := client.withContext(ctx).Keys().Retrieve()
+	if err != nil {
+		// Typesense rejects a key that isn't allowed to list keys, such as a search-only key, the
+		// same way as an invalid key. Only the health of the server is checked then, and an invalid
+		// key is reported by the first request that uses it.
+		if isAuthError(err) {
+			log.Printf("[DEBUG] The API key isn't allowed to list keys, its actions aren't checked: %s", err)
+			return nil, checkServerHealth(ctx, client)
+		}
+
+		log.Printf("[DEBUG] Actions of the API key can't be checked: %s", err)
+		return nil, nil
+	}
+
+	var matches [][]string
+	for _, key := range keys {
+		if key.ValuePrefix != "" && strings.HasPrefix(client.apiKey, key.ValuePrefix) {
+			matches = append(matches, key.Actions)
+		}
+	}
+
+	// The bootstrap key isn't listed, and keys that share their prefix can't be told apart.
+	if len(matches) != 1 {
+		return nil, nil
+	}
+
+	return matches[0], nil
+}
+
+// checkServerHealth warns when the server can't be reached with the configured credentials and
+// headers, or reports that it isn't healthy.
+func checkServerHealth(ctx context.Context, client *typesenseClient) diag.Diagnostics {
+	health := &healthResponse{}
+	if err := client.request(ctx, http.MethodGet, "/health", nil, nil, health); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Typesense can't be reached",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if !health.Ok {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Typesense isn't healthy",
+			Detail:   "The /health endpoint of the server reports that it isn't ready to serve requests.",
+		}}
+	}
+
+	return nil
+}
+
+// missingActionsDiagnostics warns once per resource type when the API key lacks actions the
+// resource needs.
+func (c *typesenseClient) missingActionsDiagnostics(name string) diag.Diagnostics {
+	if c.keyActions == nil {
+		return nil
+	}
+
+	if _, warned := c.warnedResources.LoadOrStore(name, true); warned {
+		return nil
+	}
+
+	required := resourceActions[name].read
+	if !c.readOnly {
+		required = append(append([]string{}, required...), resourceActions[name].write...)
+	}
+
+	missing := []string{}
+	for _, action := range required {
+		if !keyAllowsAction(c.keyActions, action) {
+			missing = append(missing, action)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The API key lacks actions needed by %s", name),
+		Detail: fmt.Sprintf("The API key doesn't allow %s, so managing %s may fail. "+
+			"Use a key with these actions, or set read_only in the provider configuration to only read.",
+			strings.Join(missing, ", "), name),
+	}}
+}
+
+// keyAllowsAction reports whether the actions of a key include the action, either exactly or with
+// a wildcard.
+func keyAllowsAction(actions []string, action string) bool {
+	resource := strings.SplitN(action, ":", 2)[0]
+
+	for _, a := range actions {
+		if a == "*" || a == action || a == resource+":*" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,25 @@
+package typesense
+
+import "testing"
+
+func TestKeyAllowsAction(t *testing.T) {
+	cases := []struct {
+		actions  []string
+		action   string
+		expected bool
+	}{
+		{[]string{"*"}, "collections:create", true},
+		{[]string{"collections:create"}, "collections:create", true},
+		{[]string{"collections:*"}, "collections:delete", true},
+		{[]string{"analytics/rules:*"}, "analytics/rules:upsert", true},
+		{[]string{"documents:search"}, "documents:get", false},
+		{[]string{"documents:*"}, "collections:get", false},
+		{[]string{}, "collections:get", false},
+	}
+
+	for _, c := range cases {
+		if actual := keyAllowsAction(c.actions, c.action); actual != c.expected {
+			t.Errorf("keyAllowsAction(%v, %s): expected %t, got %t", c.actions, c.action, c.expected, actual)
+		}
+	}
+}
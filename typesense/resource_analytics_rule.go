@@ -0,0 +1,255 @@
+package typesense
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTypesenseAnalyticsRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Rule that aggregates search queries or events of source collections into a destination collection",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the analytics rule",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "Type of the analytics rule",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"popular_queries", "nohits_queries", "counter", "log"}, false),
+			},
+			"params": {
+				Type:        schema.TypeList,
+				Description: "Parameters of the analytics rule",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:        schema.TypeList,
+							Description: "Collections and events the analytics are collected from",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"collections": {
+										Type:        schema.TypeList,
+										Description: "Names of the source collections",
+										Required:    true,
+										MinItems:    1,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"events": {
+										Type:        schema.TypeList,
+										Description: "Events sent to the analytics endpoint that are counted by the rule",
+										Optional:    true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"type": {
+													Type:         schema.TypeString,
+													Description:  "Type of the event",
+													Required:     true,
+													ValidateFunc: validation.StringInSlice([]string{"click", "conversion", "visit", "search", "custom"}, false),
+												},
+												"name": {
+													Type:        schema.TypeString,
+													Description: "Name of the event",
+													Required:    true,
+												},
+												"weight": {
+													Type:         schema.TypeInt,
+													Description:  "Weight added to the counter for each event",
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(1),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"destination": {
+							Type:        schema.TypeList,
+							Description: "Collection the aggregated analytics are written to",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"collection": {
+										Type:        schema.TypeString,
+										Description: "Name of the destination collection",
+										Required:    true,
+									},
+									"counter_field": {
+										Type:        schema.TypeString,
+										Description: "Field of the destination collection that is incremented by `counter` rules",
+										Optional:    true,
+									},
+								},
+							},
+						},
+						"limit": {
+							Type:         schema.TypeInt,
+							Description:  "Maximum number of queries kept in the destination collection",
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+		},
+		ReadContext:   resourceTypesenseAnalyticsRuleRead,
+		CreateContext: resourceTypesenseAnalyticsRuleUpsert,
+		UpdateContext: resourceTypesenseAnalyticsRuleUpsert,
+		DeleteContext: resourceTypesenseAnalyticsRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesenseAnalyticsRuleUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	name := d.Get("name").(string)
+	ruleSchema := &analyticsRuleSchema{
+		Type: d.Get("type").(string),
+	}
+
+	if vs := d.Get("params").([]interface{}); len(vs) > 0 && vs[0] != nil {
+		ruleSchema.Params = expandAnalyticsRuleParams(vs[0].(map[string]interface{}))
+	}
+
+	rule, err := client.upsertAnalyticsRule(ctx, name, ruleSchema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(rule.Name)
+	return resourceTypesenseAnalyticsRuleRead(ctx, d, meta)
+}
+
+func resourceTypesenseAnalyticsRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	rule, err := client.retrieveAnalyticsRule(ctx, d.Id())
+	if err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	if err := d.Set("name", rule.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("type", rule.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("params", flattenAnalyticsRuleParams(rule.Params)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceTypesenseAnalyticsRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	if err := client.deleteAnalyticsRule(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandAnalyticsRuleParams(v map[string]interface{}) analyticsRuleParams {
+	params := analyticsRuleParams{}
+
+	if vs := v["source"].([]interface{}); len(vs) > 0 && vs[0] != nil {
+		source := vs[0].(map[string]interface{})
+		params.Source.Collections = interfaceArrayToStringArray(source["collections"].([]interface{}))
+
+		for _, e := range source["events"].([]interface{}) {
+			r := e.(map[string]interface{})
+
+			event := analyticsRuleEvent{
+				Type: r["type"].(string),
+				Name: r["name"].(string),
+			}
+
+			if weight := r["weight"].(int); weight > 0 {
+				event.Weight = intPointer(weight)
+			}
+
+			params.Source.Events = append(params.Source.Events, event)
+		}
+	}
+
+	if vs := v["destination"].([]interface{}); len(vs) > 0 && vs[0] != nil {
+		destination := vs[0].(map[string]interface{})
+
+		params.Destination = &analyticsRuleDestination{
+			Collection:   destination["collection"].(string),
+			CounterField: nonEmptyStringPointer(destination["counter_field"].(string)),
+		}
+	}
+
+	if limit := v["limit"].(int); limit > 0 {
+		params.Limit = intPointer(limit)
+	}
+
+	return params
+}
+
+func flattenAnalyticsRuleParams(params analyticsRuleParams) []interface{} {
+	events := make([]interface{}, len(params.Source.Events))
+	for i, event := range params.Source.Events {
+		events[i] = map[string]interface{}{
+			"type":   event.Type,
+			"name":   event.Name,
+			"weight": intValue(event.Weight),
+		}
+	}
+
+	destination := []interface{}{}
+	if params.Destination != nil {
+		destination = append(destination, map[string]interface{}{
+			"collection":    params.Destination.Collection,
+			"counter_field": stringValue(params.Destination.CounterField),
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"source": []interface{}{
+				map[string]interface{}{
+					"collections": params.Source.Collections,
+					"events":      events,
+				},
+			},
+			"destination": destination,
+			"limit":       intValue(params.Limit),
+		},
+	}
+}
@@ -0,0 +1,190 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+func resourceTypesenseAPIKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "API key with a restricted set of actions and collections. Typesense doesn't offer an update API for keys, so every change creates a new key.",
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Internal description to identify what the key is for",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"actions": {
+				Type:        schema.TypeSet,
+				Description: "Actions allowed for the key, such as `documents:search` or `*`",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"collections": {
+				Type:        schema.TypeSet,
+				Description: "Collections the key has access to, or `*` for all collections",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"expires_at": {
+				Type:         schema.TypeInt,
+				Description:  "Unix timestamp when the key expires. Keys don't expire by default",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "Generated key. Typesense only returns it on creation, so it is empty for imported keys",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"value_prefix": {
+				Type:        schema.TypeString,
+				Description: "First characters of the generated key",
+				Computed:    true,
+			},
+		},
+		ReadContext:   resourceTypesenseAPIKeyRead,
+		CreateContext: resourceTypesenseAPIKeyCreate,
+		DeleteContext: resourceTypesenseAPIKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTypesenseAPIKeyState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesenseAPIKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	keySchema := &api.ApiKeySchema{
+		Description: d.Get("description").(string),
+		Actions:     interfaceArrayToStringArray(d.Get("actions").(*schema.Set).List()),
+		Collections: interfaceArrayToStringArray(d.Get("collections").(*schema.Set).List()),
+	}
+
+	if v, ok := d.GetOk("expires_at"); ok {
+		expiresAt := int64(v.(int))
+		keySchema.ExpiresAt = &expiresAt
+	}
+
+	key, err := client.withContext(ctx).Keys().Create(keySchema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The full key is only returned on creation, so it is stored here and never refreshed.
+	if err := d.Set("value", key.Value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(key.Id, 10))
+	return resourceTypesenseAPIKeyRead(ctx, d, meta)
+}
+
+func resourceTypesenseAPIKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	id, err := parseAPIKeyId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	key, err := client.withContext(ctx).Key(id).Retrieve()
+	if err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	if err := d.Set("description", key.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("actions", key.Actions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("collections", key.Collections); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if key.ExpiresAt != nil {
+		if err := d.Set("expires_at", int(*key.ExpiresAt)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("value_prefix", key.ValuePrefix); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceTypesenseAPIKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	id, err := parseAPIKeyId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.withContext(ctx).Key(id).Delete()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceTypesenseAPIKeyState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*typesenseClient)
+
+	id, err := parseAPIKeyId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := client.withContext(ctx).Key(id).Retrieve()
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(strconv.FormatInt(key.Id, 10))
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseAPIKeyId(input string) (int64, error) {
+	id, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid format, api key id should be numeric: %s", input)
+	}
+
+	return id, nil
+}
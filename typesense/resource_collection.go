@@ -2,15 +2,38 @@ package typesense
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/typesense/typesense-go/typesense"
-	"github.com/typesense/typesense-go/typesense/api"
 )
 
+var collectionFieldTypes = []string{
+	"string",
+	"int32",
+	"int64",
+	"float",
+	"bool",
+	"string[]",
+	"int32[]",
+	"int64[]",
+	"float[]",
+	"bool[]",
+	"geopoint",
+	"geopoint[]",
+	"object",
+	"object[]",
+	"string*",
+	"image",
+	"auto",
+}
+
 func resourceTypesenseCollection() *schema.Resource {
 	return &schema.Resource{
 		Description: "Group of related documents which are roughly equivalent to a table in a relational database.",
@@ -22,8 +45,9 @@ func resourceTypesenseCollection() *schema.Resource {
 				Required:    true,
 			},
 			"fields": {
-				Type:     schema.TypeList,
-				Required: true,
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Fields of the collection. Added, changed and removed fields are updated in place without recreating the collection, while the server reindexes the documents",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -32,44 +56,171 @@ func resourceTypesenseCollection() *schema.Resource {
 						},
 						"facet": {
 							Type:        schema.TypeBool,
-							ForceNew:    true,
 							Optional:    true,
 							Computed:    true,
 							Description: "Facetable field",
 						},
 						"index": {
 							Type:        schema.TypeBool,
-							ForceNew:    true,
 							Optional:    true,
 							Default:     true,
 							Description: "Index field",
 						},
 						"optional": {
 							Type:        schema.TypeBool,
-							ForceNew:    true,
 							Optional:    true,
 							Computed:    true,
 							Description: "Optional field",
 						},
 						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Field type",
+							ValidateFunc: validation.StringInSlice(collectionFieldTypes, false),
+						},
+						"sort": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Sortable field. Numeric fields are sortable by default",
+						},
+						"infix": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Enable infix search",
+						},
+						"locale": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Locale used to tokenize the field, such as `ja` or `th`",
+						},
+						"stem": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Enable stemming of the field values",
+						},
+						"store": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Store the field value on disk",
+						},
+						"range_index": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Optimize numeric range filters on the field",
+						},
+						"reference": {
 							Type:        schema.TypeString,
-							ForceNew:    true,
-							Required:    true,
-							Description: "Field type",
-							ValidateFunc: validation.StringInSlice([]string{
-								"string",
-								"int32",
-								"int64",
-								"float",
-								"bool",
-								"string[]",
-								"int32[]",
-								"int64[]",
-								"float[]",
-								"bool[]",
-								"geopoint",
-								"auto",
-							}, false),
+							Optional:    true,
+							Description: "Field of another collection to join on, such as `products.product_id`",
+						},
+						"async_reference": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Allow documents to be indexed before the referenced document exists",
+						},
+						"num_dim": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							Description:  "Number of dimensions of a `float[]` vector field. It is computed for `embed` fields",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"vec_dist": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							Description:  "Distance metric of a vector field",
+							ValidateFunc: validation.StringInSlice([]string{"cosine", "ip"}, false),
+						},
+						"hnsw_params": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Description: "HNSW index parameters of a vector field",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ef_construction": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"m": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+						"embed": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Generate the embeddings of a `float[]` field from other fields",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Description: "Fields the embeddings are generated from",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"model_config": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MaxItems:    1,
+										Description: "Model used to generate the embeddings",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"model_name": {
+													Type:        schema.TypeString,
+													Required:    true,
+													Description: "Name of the model, such as `ts/all-MiniLM-L12-v2` or `openai/text-embedding-3-small`",
+												},
+												"api_key": {
+													Type:        schema.TypeString,
+													Optional:    true,
+													Sensitive:   true,
+													Description: "API key of the model provider. The server masks it, so it is never read back",
+												},
+												"url": {
+													Type:        schema.TypeString,
+													Optional:    true,
+													Description: "URL of a custom OpenAI-compatible API",
+												},
+												"indexing_prefix": {
+													Type:        schema.TypeString,
+													Optional:    true,
+													Description: "Prefix added to the field values before they are embedded",
+												},
+												"query_prefix": {
+													Type:        schema.TypeString,
+													Optional:    true,
+													Description: "Prefix added to the search query before it is embedded",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"drop": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Drop the field from the collection, such as a field detected by an `auto` field",
 						},
 					},
 				},
@@ -79,11 +230,46 @@ func resourceTypesenseCollection() *schema.Resource {
 				ForceNew: true,
 				Optional: true,
 			},
+			"token_separators": {
+				Type:        schema.TypeList,
+				Description: "Characters used to split words in addition to the space and new-line characters",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringLenBetween(1, 1),
+				},
+			},
+			"symbols_to_index": {
+				Type:        schema.TypeList,
+				Description: "Special characters that are indexed instead of being removed",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringLenBetween(1, 1),
+				},
+			},
+			"enable_nested_fields": {
+				Type:        schema.TypeBool,
+				Description: "Index the fields of nested objects",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"metadata": {
+				Type:             schema.TypeString,
+				Description:      "Free-form JSON object stored with the collection, such as ownership tags",
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
 			"num_documents": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
+		CustomizeDiff: resourceTypesenseCollectionCustomizeDiff,
 		ReadContext:   resourceTypesenseCollectionRead,
 		CreateContext: resourceTypesenseCollectionCreate,
 		UpdateContext: resourceTypesenseCollectionUpdate,
@@ -91,49 +277,24 @@ func resourceTypesenseCollection() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 	}
 }
 
 func resourceTypesenseCollectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
-
-	schema := &api.CollectionSchema{}
-
-	if v := d.Get("name"); v != "" {
-		schema.Name = v.(string)
-	}
-
-	if v := d.Get("default_sorting_field"); v != "" {
-		schema.DefaultSortingField = v.(string)
-	}
-
-	fields := []api.Field{}
-	for _, vs := range d.Get("fields").([]interface{}) {
-		v := vs.(map[string]interface{})
-
-		field := api.Field{
-			Name: v["name"].(string),
-			Type: v["type"].(string),
-		}
-
-		if value := v["facet"]; value != "" {
-			field.Facet = value.(bool)
-		}
+	client := meta.(*typesenseClient)
 
-		if value := v["optional"]; value != "" {
-			field.Optional = value.(bool)
-		}
-
-		if value := v["index"]; value != "" {
-			field.Index = boolPointer(value.(bool))
-		}
-
-		fields = append(fields, field)
+	schema, err := expandCollectionSchema(d, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	schema.Fields = fields
-
-	collection, err := client.Collections().Create(schema)
+	collection, err := client.createCollection(ctx, schema)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -143,16 +304,15 @@ func resourceTypesenseCollectionCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceTypesenseCollectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
 	id := d.Id()
 
-	collection, err := client.Collection(id).Retrieve()
+	collection, err := client.retrieveCollection(ctx, id)
 	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
+		return readDiagnostics(d, err)
 	}
 
 	log.Printf("[DEBUG] Got collection name:%s\n", collection.Name)
@@ -161,7 +321,12 @@ func resourceTypesenseCollectionRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("fields", flattenCollectionFields(collection.Fields)); err != nil {
+	prior := d.Get("fields").([]interface{})
+	fields := sortCollectionFieldsByPrior(flattenCollectionFields(collection.Fields), prior)
+	fields = mergeDroppedCollectionFields(fields, prior)
+	mergeCollectionFieldEmbedAPIKeys(fields, prior)
+
+	if err := d.Set("fields", fields); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -169,6 +334,10 @@ func resourceTypesenseCollectionRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	if err := flattenCollectionSettings(d, collection); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("num_documents", collection.NumDocuments); err != nil {
 		return diag.FromErr(err)
 	}
@@ -176,91 +345,467 @@ func resourceTypesenseCollectionRead(ctx context.Context, d *schema.ResourceData
 	return diags
 }
 
-// Typesense doesn't offer any update API for collections. The team has plans to offer it, see the following issue for details.
-// https://github.com/typesense/typesense/issues/96
+// Typesense can add and drop fields of an existing collection, so fields are updated in place
+// instead of recreating the collection and losing every document.
+// https://typesense.org/docs/latest/api/collections.html#update-or-alter-a-collection
 func resourceTypesenseCollectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	id := d.Id()
 
-	_, err := client.Collection(id).Delete()
+	update := &collectionUpdateSchema{}
+
+	if d.HasChange("fields") {
+		o, n := d.GetChange("fields")
+
+		update.Fields = diffCollectionFields(
+			expandCollectionFields(o.([]interface{}), nil),
+			expandCollectionFields(n.([]interface{}), configuredListAttributes(d, "fields")),
+		)
+	}
+
+	if d.HasChange("metadata") {
+		metadata, err := expandJSONObject(d.Get("metadata").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Removing the metadata attribute clears it on the server.
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+
+		update.Metadata = metadata
+	}
+
+	if len(update.Fields) > 0 || update.Metadata != nil {
+		log.Printf("[DEBUG] Updating %d fields of collection name:%s\n", len(update.Fields), id)
+
+		if err := client.updateCollection(ctx, id, update); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTypesenseCollectionRead(ctx, d, meta)
+}
+
+func resourceTypesenseCollectionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, v := range d.Get("fields").([]interface{}) {
+		field, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if embed := field["embed"].([]interface{}); len(embed) > 0 && field["type"] != "float[]" {
+			return fmt.Errorf("field %s: embed is only supported on float[] fields, got %s", field["name"], field["type"])
+		}
+	}
+
+	return nil
+}
+
+func resourceTypesenseCollectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	_, err := client.withContext(ctx).Collection(id).Delete()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId("")
+	return diags
+}
 
-	schema := &api.CollectionSchema{}
+// flattenCollectionSettings sets the collection-level settings shared by the resource and the
+// data source.
+func flattenCollectionSettings(d *schema.ResourceData, collection *collectionResponse) error {
+	if collection.TokenSeparators != nil {
+		if err := d.Set("token_separators", *collection.TokenSeparators); err != nil {
+			return err
+		}
+	}
 
-	if v := d.Get("name"); v != "" {
-		schema.Name = v.(string)
+	if collection.SymbolsToIndex != nil {
+		if err := d.Set("symbols_to_index", *collection.SymbolsToIndex); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("enable_nested_fields", boolValue(collection.EnableNestedFields)); err != nil {
+		return err
+	}
+
+	metadata, err := flattenJSON(collection.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("metadata", metadata)
+}
+
+// expandCollectionSchema builds the schema of a new collection from the collection attributes,
+// which are shared by typesense_collection and typesense_collection_rollout.
+func expandCollectionSchema(d *schema.ResourceData, name string) (*collectionSchema, error) {
+	schema := &collectionSchema{
+		Name: name,
 	}
 
 	if v := d.Get("default_sorting_field"); v != "" {
-		schema.DefaultSortingField = v.(string)
+		schema.DefaultSortingField = stringPointer(v.(string))
+	}
+
+	if v, ok := d.GetOk("token_separators"); ok {
+		separators := interfaceArrayToStringArray(v.([]interface{}))
+		schema.TokenSeparators = &separators
 	}
 
-	fields := []api.Field{}
-	for _, vs := range d.Get("fields").([]interface{}) {
-		v := vs.(map[string]interface{})
+	if v, ok := d.GetOk("symbols_to_index"); ok {
+		symbols := interfaceArrayToStringArray(v.([]interface{}))
+		schema.SymbolsToIndex = &symbols
+	}
+
+	if v, ok := d.GetOkExists("enable_nested_fields"); ok {
+		schema.EnableNestedFields = boolPointer(v.(bool))
+	}
 
-		field := api.Field{
-			Name: v["name"].(string),
-			Type: v["type"].(string),
+	metadata, err := expandJSONObject(d.Get("metadata").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Metadata = metadata
+
+	fields := []collectionField{}
+	for _, field := range expandCollectionFields(d.Get("fields").([]interface{}), configuredListAttributes(d, "fields")) {
+		if !boolValue(field.Drop) {
+			fields = append(fields, field)
+		}
+	}
+
+	schema.Fields = fields
+
+	return schema, nil
+}
+
+// expandCollectionFields builds the fields sent to the server. Optional attributes which are
+// computed by the server are only sent when they are set in the configuration, because their
+// defaults depend on the field type. Passing nil as configured keeps every attribute.
+func expandCollectionFields(inputs []interface{}, configured []map[string]bool) []collectionField {
+	fields := make([]collectionField, 0, len(inputs))
+
+	for i, input := range inputs {
+		v := input.(map[string]interface{})
+
+		isSet := func(key string) bool {
+			if configured == nil {
+				return true
+			}
+
+			return i < len(configured) && configured[i][key]
+		}
+
+		optionalBool := func(key string) *bool {
+			if !isSet(key) {
+				return nil
+			}
+
+			return boolPointer(v[key].(bool))
+		}
+
+		optionalString := func(key string) *string {
+			if value := v[key].(string); isSet(key) && value != "" {
+				return stringPointer(value)
+			}
+
+			return nil
+		}
+
+		field := collectionField{
+			Name:           v["name"].(string),
+			Type:           v["type"].(string),
+			Facet:          optionalBool("facet"),
+			Optional:       optionalBool("optional"),
+			Index:          optionalBool("index"),
+			Sort:           optionalBool("sort"),
+			Infix:          optionalBool("infix"),
+			Locale:         optionalString("locale"),
+			Stem:           optionalBool("stem"),
+			Store:          optionalBool("store"),
+			RangeIndex:     optionalBool("range_index"),
+			Reference:      optionalString("reference"),
+			AsyncReference: optionalBool("async_reference"),
+			VecDist:        optionalString("vec_dist"),
+		}
+
+		if value := v["num_dim"].(int); isSet("num_dim") && value > 0 {
+			field.NumDim = intPointer(value)
 		}
 
-		if value := v["facet"]; value != "" {
-			field.Facet = value.(bool)
+		if vs := v["hnsw_params"].([]interface{}); isSet("hnsw_params") && len(vs) > 0 && vs[0] != nil {
+			params := vs[0].(map[string]interface{})
+			field.HnswParams = &collectionFieldHnswParams{}
+
+			if value := params["ef_construction"].(int); value > 0 {
+				field.HnswParams.EfConstruction = intPointer(value)
+			}
+
+			if value := params["m"].(int); value > 0 {
+				field.HnswParams.M = intPointer(value)
+			}
 		}
 
-		if value := v["optional"]; value != "" {
-			field.Optional = value.(bool)
+		if vs := v["embed"].([]interface{}); len(vs) > 0 && vs[0] != nil {
+			field.Embed = expandCollectionFieldEmbed(vs[0].(map[string]interface{}))
 		}
 
-		if value := v["index"]; value != "" {
-			field.Index = boolPointer(value.(bool))
+		if drop, _ := v["drop"].(bool); drop {
+			field.Drop = boolPointer(true)
 		}
 
 		fields = append(fields, field)
 	}
 
-	schema.Fields = fields
+	return fields
+}
 
-	_, err = client.Collections().Create(schema)
-	if err != nil {
-		return diag.FromErr(err)
+func expandCollectionFieldEmbed(v map[string]interface{}) *collectionFieldEmbed {
+	embed := &collectionFieldEmbed{
+		From: interfaceArrayToStringArray(v["from"].([]interface{})),
 	}
 
-	return resourceTypesenseCurationRead(ctx, d, meta)
+	if vs := v["model_config"].([]interface{}); len(vs) > 0 && vs[0] != nil {
+		config := vs[0].(map[string]interface{})
+
+		embed.ModelConfig = collectionFieldEmbedModelConfig{
+			ModelName:      config["model_name"].(string),
+			ApiKey:         nonEmptyStringPointer(config["api_key"].(string)),
+			Url:            nonEmptyStringPointer(config["url"].(string)),
+			IndexingPrefix: nonEmptyStringPointer(config["indexing_prefix"].(string)),
+			QueryPrefix:    nonEmptyStringPointer(config["query_prefix"].(string)),
+		}
+	}
+
+	return embed
 }
 
-func resourceTypesenseCollectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+// diffCollectionFields returns the fields of a collection update request. Typesense can't alter
+// an existing field, so a changed field is dropped and added again in the same request. Drops
+// have to come before the additions.
+func diffCollectionFields(oldFields, newFields []collectionField) []collectionField {
+	olds := make(map[string]collectionField, len(oldFields))
+	for _, field := range oldFields {
+		if !boolValue(field.Drop) {
+			olds[field.Name] = field
+		}
+	}
 
-	var diags diag.Diagnostics
+	news := make(map[string]collectionField, len(newFields))
+	for _, field := range newFields {
+		news[field.Name] = field
+	}
 
-	id := d.Id()
+	drops := []collectionField{}
+	adds := []collectionField{}
 
-	_, err := client.Collection(id).Delete()
+	for _, field := range oldFields {
+		if _, ok := news[field.Name]; !ok && !boolValue(field.Drop) {
+			drops = append(drops, collectionField{Name: field.Name, Drop: boolPointer(true)})
+		}
+	}
+
+	for _, field := range newFields {
+		old, ok := olds[field.Name]
+
+		if boolValue(field.Drop) {
+			if ok {
+				drops = append(drops, collectionField{Name: field.Name, Drop: boolPointer(true)})
+			}
+
+			continue
+		}
+
+		if ok && !isCollectionFieldChanged(old, field) {
+			continue
+		}
+
+		if ok {
+			drops = append(drops, collectionField{Name: field.Name, Drop: boolPointer(true)})
+		}
+
+		adds = append(adds, field)
+	}
+
+	return append(drops, adds...)
+}
+
+// collectionFieldDefaults are the values of the field attributes that aren't computed by the
+// server, once they are removed from the configuration.
+var collectionFieldDefaults = map[string]interface{}{
+	"index":     true,
+	"reference": nil,
+	"embed":     nil,
+}
+
+// isCollectionFieldChanged compares the attributes set on the new field, since the attributes
+// that aren't configured are left to the server. Attributes that the server doesn't compute are
+// also compared with their default once they are removed from the configuration.
+func isCollectionFieldChanged(oldField, newField collectionField) bool {
+	olds, err := collectionFieldAttributes(oldField)
 	if err != nil {
-		return diag.FromErr(err)
+		return true
 	}
 
-	d.SetId("")
-	return diags
+	news, err := collectionFieldAttributes(newField)
+	if err != nil {
+		return true
+	}
+
+	for key, value := range news {
+		if !reflect.DeepEqual(olds[key], value) {
+			return true
+		}
+	}
+
+	for key, value := range olds {
+		defaultValue, ok := collectionFieldDefaults[key]
+		if _, set := news[key]; ok && !set && !reflect.DeepEqual(value, defaultValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func collectionFieldAttributes(field collectionField) (map[string]interface{}, error) {
+	b, err := json.Marshal(field)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := map[string]interface{}{}
+	if err := json.Unmarshal(b, &attrs); err != nil {
+		return nil, err
+	}
+
+	return attrs, nil
+}
+
+// sortCollectionFieldsByPrior orders the fields like the prior state or configuration. The server
+// appends added fields, and changed fields which are dropped and added again, to the end of its
+// list, which would otherwise show up as a diff of every later field. Fields that aren't known yet
+// keep the server order after the known ones.
+func sortCollectionFieldsByPrior(fields []interface{}, prior []interface{}) []interface{} {
+	positions := map[string]int{}
+	for i, v := range prior {
+		if field, ok := v.(map[string]interface{}); ok {
+			positions[field["name"].(string)] = i
+		}
+	}
+
+	position := func(v interface{}) int {
+		if i, ok := positions[v.(map[string]interface{})["name"].(string)]; ok {
+			return i
+		}
+
+		return len(prior)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return position(fields[i]) < position(fields[j])
+	})
+
+	return fields
 }
 
-func flattenCollectionFields(fields []api.Field) []interface{} {
+// mergeDroppedCollectionFields keeps the fields marked with drop in the state, since the server
+// doesn't return them anymore.
+func mergeDroppedCollectionFields(fields []interface{}, prior []interface{}) []interface{} {
+	for i, v := range prior {
+		field, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if drop, _ := field["drop"].(bool); !drop {
+			continue
+		}
+
+		if i > len(fields) {
+			i = len(fields)
+		}
+
+		fields = append(fields[:i], append([]interface{}{field}, fields[i:]...)...)
+	}
+
+	return fields
+}
+
+// mergeCollectionFieldEmbedAPIKeys keeps the model API keys of the prior state, since the server
+// only returns them masked.
+func mergeCollectionFieldEmbedAPIKeys(fields []interface{}, prior []interface{}) {
+	apiKeys := map[string]string{}
+	for _, v := range prior {
+		if apiKey := embedModelConfigOfField(v)["api_key"]; apiKey != nil {
+			apiKeys[v.(map[string]interface{})["name"].(string)] = apiKey.(string)
+		}
+	}
+
+	for _, v := range fields {
+		config := embedModelConfigOfField(v)
+		if config == nil {
+			continue
+		}
+
+		config["api_key"] = apiKeys[v.(map[string]interface{})["name"].(string)]
+	}
+}
+
+func embedModelConfigOfField(v interface{}) map[string]interface{} {
+	field, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	embed, ok := field["embed"].([]interface{})
+	if !ok || len(embed) == 0 || embed[0] == nil {
+		return nil
+	}
+
+	configs, ok := embed[0].(map[string]interface{})["model_config"].([]interface{})
+	if !ok || len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	return configs[0].(map[string]interface{})
+}
+
+func flattenCollectionFields(fields []collectionField) []interface{} {
 	if fields != nil {
 		fis := make([]interface{}, len(fields))
 
 		for i, field := range fields {
 			fi := make(map[string]interface{})
 			fi["name"] = field.Name
-			fi["facet"] = field.Facet
-			fi["index"] = field.Index
-			fi["optional"] = field.Optional
+			fi["facet"] = boolValue(field.Facet)
+			fi["index"] = field.Index == nil || *field.Index
+			fi["optional"] = boolValue(field.Optional)
 			fi["type"] = field.Type
+			fi["sort"] = boolValue(field.Sort)
+			fi["infix"] = boolValue(field.Infix)
+			fi["locale"] = stringValue(field.Locale)
+			fi["stem"] = boolValue(field.Stem)
+			fi["store"] = field.Store == nil || *field.Store
+			fi["range_index"] = boolValue(field.RangeIndex)
+			fi["reference"] = stringValue(field.Reference)
+			fi["async_reference"] = boolValue(field.AsyncReference)
+			fi["num_dim"] = intValue(field.NumDim)
+			fi["vec_dist"] = stringValue(field.VecDist)
+			fi["hnsw_params"] = flattenCollectionFieldHnswParams(field.HnswParams)
+			fi["embed"] = flattenCollectionFieldEmbed(field.Embed)
 			fis[i] = fi
 		}
 
@@ -269,3 +814,37 @@ func flattenCollectionFields(fields []api.Field) []interface{} {
 
 	return make([]interface{}, 0)
 }
+
+func flattenCollectionFieldHnswParams(params *collectionFieldHnswParams) []interface{} {
+	if params == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"ef_construction": intValue(params.EfConstruction),
+			"m":               intValue(params.M),
+		},
+	}
+}
+
+// flattenCollectionFieldEmbed leaves the model API key out, since the server only returns it masked.
+func flattenCollectionFieldEmbed(embed *collectionFieldEmbed) []interface{} {
+	if embed == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"from": embed.From,
+			"model_config": []interface{}{
+				map[string]interface{}{
+					"model_name":      embed.ModelConfig.ModelName,
+					"url":             stringValue(embed.ModelConfig.Url),
+					"indexing_prefix": stringValue(embed.ModelConfig.IndexingPrefix),
+					"query_prefix":    stringValue(embed.ModelConfig.QueryPrefix),
+				},
+			},
+		},
+	}
+}
@@ -2,10 +2,10 @@ package typesense
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 	"github.com/typesense/typesense-go/typesense/api"
 )
 
@@ -32,18 +32,24 @@ func resourceTypesenseCollectionAlias() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
 func resourceTypesenseCollectionAliasUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	name := d.Get("name").(string)
 	aliasSchema := &api.CollectionAliasSchema{
 		CollectionName: d.Get("collection_name").(string),
 	}
 
-	alias, err := client.Aliases().Upsert(name, aliasSchema)
+	alias, err := client.withContext(ctx).Aliases().Upsert(name, aliasSchema)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -53,16 +59,15 @@ func resourceTypesenseCollectionAliasUpsert(ctx context.Context, d *schema.Resou
 }
 
 func resourceTypesenseCollectionAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
 	id := d.Id()
 
-	alias, err := client.Alias(id).Retrieve()
+	alias, err := client.withContext(ctx).Alias(id).Retrieve()
 	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
+		return readDiagnostics(d, err)
 	}
 
 	if err := d.Set("name", alias.Name); err != nil {
@@ -77,13 +82,13 @@ func resourceTypesenseCollectionAliasRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceTypesenseCollectionAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
 	id := d.Id()
 
-	_, err := client.Alias(id).Delete()
+	_, err := client.withContext(ctx).Alias(id).Delete()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,647 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+// collectionRolloutSchemaKeys are the collection attributes shared with typesense_collection.
+var collectionRolloutSchemaKeys = []string{
+	"fields",
+	"default_sorting_field",
+	"token_separators",
+	"symbols_to_index",
+	"enable_nested_fields",
+	"metadata",
+}
+
+func resourceTypesenseCollectionRollout() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"alias_name": {
+			Type:        schema.TypeString,
+			Description: "Name of the alias that points to the current generation. The collections are named `<alias_name>_v<generation>`",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"documents": {
+			Type:        schema.TypeList,
+			Description: "JSONL documents imported into every new generation before the alias is moved",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"file": {
+						Type:         schema.TypeString,
+						Description:  "Path of the JSONL file to import",
+						Optional:     true,
+						ExactlyOneOf: []string{"documents.0.file", "documents.0.content"},
+					},
+					"content": {
+						Type:         schema.TypeString,
+						Description:  "JSONL content to import, one document per line",
+						Optional:     true,
+						ExactlyOneOf: []string{"documents.0.file", "documents.0.content"},
+					},
+					"batch_size": {
+						Type:         schema.TypeInt,
+						Description:  "Number of documents imported at a time by the server",
+						Optional:     true,
+						Default:      40,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"dirty_values": {
+						Type:         schema.TypeString,
+						Description:  "How values that don't match the field type are handled, one of `coerce_or_reject`, `coerce_or_drop`, `drop` or `reject`",
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"coerce_or_reject", "coerce_or_drop", "drop", "reject"}, false),
+					},
+				},
+			},
+		},
+		"min_documents": {
+			Type:         schema.TypeInt,
+			Description:  "Number of documents a new generation must have before the alias is moved",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"health_check": {
+			Type:        schema.TypeList,
+			Description: "Search that must find enough documents in a new generation before the alias is moved",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"q": {
+						Type:        schema.TypeString,
+						Description: "Search query, or `*` to match every document",
+						Required:    true,
+					},
+					"query_by": {
+						Type:        schema.TypeString,
+						Description: "Fields to query",
+						Optional:    true,
+					},
+					"filter_by": {
+						Type:        schema.TypeString,
+						Description: "Filter applied to the results",
+						Optional:    true,
+					},
+					"min_found": {
+						Type:         schema.TypeInt,
+						Description:  "Number of documents the search must find",
+						Optional:     true,
+						Default:      1,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+				},
+			},
+		},
+		"keep_generations": {
+			Type:         schema.TypeInt,
+			Description:  "Number of previous generations kept for rollback. Older generations are deleted",
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Description: "Arbitrary values that roll out a new generation when they change, such as the version of the data",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"generation": {
+			Type:        schema.TypeInt,
+			Description: "Generation the alias points to",
+			Computed:    true,
+		},
+		"collection_name": {
+			Type:        schema.TypeString,
+			Description: "Name of the collection the alias points to",
+			Computed:    true,
+		},
+		"previous_collection_names": {
+			Type:        schema.TypeList,
+			Description: "Names of the previous generations kept for rollback, newest first",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"content_hash": {
+			Type:        schema.TypeString,
+			Description: "SHA-256 digest of the imported documents. Empty when the current generation failed or is missing, which rolls out a new generation",
+			Computed:    true,
+		},
+	}
+
+	// Changes to the collection attributes roll out a new generation instead of replacing the resource.
+	collection := resourceTypesenseCollection().Schema
+	for _, key := range collectionRolloutSchemaKeys {
+		v := *collection[key]
+		v.ForceNew = false
+		s[key] = &v
+	}
+
+	// Fields can't be dropped from a generation, they are just left out of the next one.
+	fields := *collection["fields"].Elem.(*schema.Resource)
+	fields.Schema = map[string]*schema.Schema{}
+	for k, v := range collection["fields"].Elem.(*schema.Resource).Schema {
+		if k != "drop" {
+			fields.Schema[k] = v
+		}
+	}
+	s["fields"].Elem = &fields
+
+	return &schema.Resource{
+		Description: "Collection that is rolled out as a new generation behind an alias on every change. The alias is only moved once the new generation passes its health checks.",
+		Schema:      s,
+
+		CustomizeDiff: resourceTypesenseCollectionRolloutCustomizeDiff,
+		ReadContext:   resourceTypesenseCollectionRolloutRead,
+		CreateContext: resourceTypesenseCollectionRolloutCreate,
+		UpdateContext: resourceTypesenseCollectionRolloutUpdate,
+		DeleteContext: resourceTypesenseCollectionRolloutDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTypesenseCollectionRolloutState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesenseCollectionRolloutCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourceTypesenseCollectionCustomizeDiff(ctx, d, meta); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("documents") {
+		return setNewCollectionRolloutGeneration(d, true)
+	}
+
+	hash := documentsImportContentHash(nil)
+	if vs := d.Get("documents").([]interface{}); len(vs) > 0 && vs[0] != nil {
+		v := vs[0].(map[string]interface{})
+
+		content, err := readDocumentsImportContent(v["file"].(string), v["content"].(string))
+		if err != nil {
+			return err
+		}
+
+		hash = documentsImportContentHash(content)
+	}
+
+	oldHash := d.Get("content_hash").(string)
+	if hash != oldHash {
+		if err := d.SetNew("content_hash", hash); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	changed := isCollectionRolloutContentChanged(oldHash, hash, d.HasChange("triggers"))
+	for _, key := range collectionRolloutSchemaKeys {
+		changed = changed || d.HasChange(key)
+	}
+
+	if changed {
+		return setNewCollectionRolloutGeneration(d, false)
+	}
+
+	return nil
+}
+
+func setNewCollectionRolloutGeneration(d *schema.ResourceDiff, contentUnknown bool) error {
+	keys := []string{"generation", "collection_name", "previous_collection_names"}
+	if contentUnknown {
+		keys = append(keys, "content_hash")
+	}
+
+	for _, key := range keys {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceTypesenseCollectionRolloutCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := rolloutCollectionGeneration(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceTypesenseCollectionRolloutRead(ctx, d, meta)...)
+}
+
+func resourceTypesenseCollectionRolloutRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	alias, err := client.withContext(ctx).Alias(d.Id()).Retrieve()
+	if err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	if err := d.Set("alias_name", alias.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("collection_name", alias.CollectionName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if generation, ok := parseCollectionRolloutGeneration(alias.Name, alias.CollectionName); ok {
+		if err := d.Set("generation", generation); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	collection, err := client.retrieveCollection(ctx, alias.CollectionName)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return diag.FromErr(err)
+		}
+
+		// The alias points to a collection that was deleted. Forgetting the content hash rolls out
+		// a new generation on the next apply, instead of creating the first generation again.
+		log.Printf("[WARN] %s points to %s, which doesn't exist anymore", alias.Name, alias.CollectionName)
+		if err := d.Set("content_hash", ""); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diags
+	}
+
+	prior := d.Get("fields").([]interface{})
+	fields := sortCollectionFieldsByPrior(flattenCollectionFields(collection.Fields), prior)
+	mergeCollectionFieldEmbedAPIKeys(fields, prior)
+
+	if err := d.Set("fields", fields); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("default_sorting_field", collection.DefaultSortingField); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenCollectionSettings(d, collection); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// collectionRolloutImportedContentHash is the content hash of a rollout that was imported. Its
+// documents can't be read back, so the configured documents and triggers are adopted on the next
+// apply without rolling out a new generation.
+const collectionRolloutImportedContentHash = "imported"
+
+// isCollectionRolloutContentChanged reports whether the documents or triggers changed. An empty
+// old hash means the current generation failed or is missing, so it always rolls out again.
+func isCollectionRolloutContentChanged(oldHash, newHash string, triggersChanged bool) bool {
+	if oldHash == collectionRolloutImportedContentHash {
+		return false
+	}
+
+	return oldHash != newHash || triggersChanged
+}
+
+// resourceTypesenseCollectionRolloutState imports a rollout by its alias name, together with the
+// previous generations that are still kept.
+func resourceTypesenseCollectionRolloutState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*typesenseClient)
+
+	alias, err := client.withContext(ctx).Alias(d.Id()).Retrieve()
+	if err != nil {
+		return nil, err
+	}
+
+	current, ok := parseCollectionRolloutGeneration(alias.Name, alias.CollectionName)
+	if !ok {
+		return nil, fmt.Errorf("alias %s points to %s, which isn't named %s_v<generation>", alias.Name, alias.CollectionName, alias.Name)
+	}
+
+	collections, err := client.listCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	generations := map[int]string{}
+	for _, collection := range collections {
+		if generation, ok := parseCollectionRolloutGeneration(alias.Name, collection.Name); ok && generation < current {
+			generations[generation] = collection.Name
+		}
+	}
+
+	keys := make([]int, 0, len(generations))
+	for generation := range generations {
+		keys = append(keys, generation)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(keys)))
+
+	previous := make([]string, len(keys))
+	for i, generation := range keys {
+		previous[i] = generations[generation]
+	}
+
+	if err := d.Set("previous_collection_names", previous); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("content_hash", collectionRolloutImportedContentHash); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTypesenseCollectionRolloutUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	o, n := d.GetChange("content_hash")
+	changed := isCollectionRolloutContentChanged(o.(string), n.(string), d.HasChange("triggers"))
+	for _, key := range collectionRolloutSchemaKeys {
+		changed = changed || d.HasChange(key)
+	}
+
+	if changed {
+		diags := rolloutCollectionGeneration(ctx, d, meta)
+		if diags.HasError() {
+			return append(diags, resetCollectionRolloutGeneration(d)...)
+		}
+
+		return append(diags, resourceTypesenseCollectionRolloutRead(ctx, d, meta)...)
+	}
+
+	if d.HasChange("keep_generations") {
+		previous := interfaceArrayToStringArray(d.Get("previous_collection_names").([]interface{}))
+		kept, diags := pruneCollectionGenerations(ctx, client, previous, d.Get("keep_generations").(int))
+
+		if err := d.Set("previous_collection_names", kept); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		return append(diags, resourceTypesenseCollectionRolloutRead(ctx, d, meta)...)
+	}
+
+	return resourceTypesenseCollectionRolloutRead(ctx, d, meta)
+}
+
+// resetCollectionRolloutGeneration keeps the current generation in the state after a failed
+// rollout, and forgets the content hash so that the next apply rolls out again.
+func resetCollectionRolloutGeneration(d *schema.ResourceData) diag.Diagnostics {
+	for _, key := range []string{"generation", "collection_name", "previous_collection_names"} {
+		o, _ := d.GetChange(key)
+		if err := d.Set(key, o); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("content_hash", ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceTypesenseCollectionRolloutDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	if _, err := client.withContext(ctx).Alias(d.Id()).Delete(); err != nil && !isNotFoundError(err) {
+		return diag.FromErr(err)
+	}
+
+	names := []string{d.Get("collection_name").(string)}
+	names = append(names, interfaceArrayToStringArray(d.Get("previous_collection_names").([]interface{}))...)
+
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+
+		if _, err := client.withContext(ctx).Collection(name).Delete(); err != nil && !isNotFoundError(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// rolloutCollectionGeneration creates the next generation, imports the documents and checks
+// its health before the alias is moved to it. A generation that fails is deleted again, so the
+// alias keeps pointing to the current generation.
+func rolloutCollectionGeneration(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	aliasName := d.Get("alias_name").(string)
+
+	o, _ := d.GetChange("generation")
+	generation := o.(int) + 1
+	name := fmt.Sprintf("%s_v%d", aliasName, generation)
+
+	collectionSchema, err := expandCollectionSchema(d, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := client.createCollection(ctx, collectionSchema); err != nil {
+		return diag.FromErr(err)
+	}
+
+	discard := func(diags diag.Diagnostics) diag.Diagnostics {
+		if _, err := client.withContext(ctx).Collection(name).Delete(); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to delete the failed generation %s", name),
+				Detail:   err.Error(),
+			})
+		}
+
+		return diags
+	}
+
+	hash := documentsImportContentHash(nil)
+	if vs := d.Get("documents").([]interface{}); len(vs) > 0 && vs[0] != nil {
+		v := vs[0].(map[string]interface{})
+
+		content, err := readDocumentsImportContent(v["file"].(string), v["content"].(string))
+		if err != nil {
+			return discard(diag.FromErr(err))
+		}
+
+		params := url.Values{}
+		params.Set("action", "create")
+		params.Set("batch_size", strconv.Itoa(v["batch_size"].(int)))
+		if dirtyValues := v["dirty_values"].(string); dirtyValues != "" {
+			params.Set("dirty_values", dirtyValues)
+		}
+
+		results, err := client.importDocuments(ctx, name, params, content)
+		if err != nil {
+			return discard(diag.FromErr(err))
+		}
+
+		failed := 0
+		for i, result := range results {
+			if result.Success {
+				continue
+			}
+
+			failed++
+			if failed <= maxDocumentsImportDiagnostics {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Failed to import document %d into %s", i+1, name),
+					Detail:   result.Error,
+				})
+			}
+		}
+
+		if failed > 0 {
+			return discard(diags)
+		}
+
+		hash = documentsImportContentHash(content)
+	}
+
+	if err := checkCollectionGenerationHealth(ctx, client, d, name); err != nil {
+		return discard(diag.FromErr(err))
+	}
+
+	if _, err := client.withContext(ctx).Aliases().Upsert(aliasName, &api.CollectionAliasSchema{CollectionName: name}); err != nil {
+		return discard(diag.FromErr(err))
+	}
+
+	d.SetId(aliasName)
+
+	previous := []string{}
+	oldName, _ := d.GetChange("collection_name")
+	if oldName.(string) != "" {
+		previous = append(previous, oldName.(string))
+	}
+
+	oldPrevious, _ := d.GetChange("previous_collection_names")
+	previous = append(previous, interfaceArrayToStringArray(oldPrevious.([]interface{}))...)
+
+	kept, pruneDiags := pruneCollectionGenerations(ctx, client, previous, d.Get("keep_generations").(int))
+	diags = append(diags, pruneDiags...)
+
+	if err := d.Set("generation", generation); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set("collection_name", name); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set("previous_collection_names", kept); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set("content_hash", hash); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+func checkCollectionGenerationHealth(ctx context.Context, client *typesenseClient, d *schema.ResourceData, name string) error {
+	if minDocuments := d.Get("min_documents").(int); minDocuments > 0 {
+		collection, err := client.retrieveCollection(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if collection.NumDocuments < int64(minDocuments) {
+			return fmt.Errorf("health check of %s failed: it has %d documents, expected at least %d", name, collection.NumDocuments, minDocuments)
+		}
+	}
+
+	if vs := d.Get("health_check").([]interface{}); len(vs) > 0 && vs[0] != nil {
+		v := vs[0].(map[string]interface{})
+
+		params := url.Values{}
+		params.Set("q", v["q"].(string))
+		for _, key := range []string{"query_by", "filter_by"} {
+			if s := v[key].(string); s != "" {
+				params.Set(key, s)
+			}
+		}
+
+		result, err := client.search(ctx, name, params)
+		if err != nil {
+			return fmt.Errorf("health check search of %s failed: %s", name, err)
+		}
+
+		if minFound := v["min_found"].(int); result.Found < minFound {
+			return fmt.Errorf("health check search of %s found %d documents, expected at least %d", name, result.Found, minFound)
+		}
+	}
+
+	return nil
+}
+
+// pruneCollectionGenerations deletes the generations after the first keep ones. Generations that
+// can't be deleted are kept, so that deleting them is retried by the next rollout.
+func pruneCollectionGenerations(ctx context.Context, client *typesenseClient, previous []string, keep int) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(previous) <= keep {
+		return previous, diags
+	}
+
+	kept := append([]string{}, previous[:keep]...)
+	for _, name := range previous[keep:] {
+		if _, err := client.withContext(ctx).Collection(name).Delete(); err != nil && !isNotFoundError(err) {
+			kept = append(kept, name)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to delete the previous generation %s", name),
+				Detail:   err.Error(),
+			})
+		}
+	}
+
+	return kept, diags
+}
+
+func parseCollectionRolloutGeneration(aliasName, collectionName string) (int, bool) {
+	matches := regexp.MustCompile(`^` + regexp.QuoteMeta(aliasName) + `_v(\d+)$`).FindStringSubmatch(collectionName)
+	if matches == nil {
+		return 0, false
+	}
+
+	generation, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return generation, true
+}
@@ -0,0 +1,87 @@
+package typesense
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIsCollectionRolloutContentChanged(t *testing.T) {
+	cases := []struct {
+		name            string
+		oldHash         string
+		newHash         string
+		triggersChanged bool
+		expected        bool
+	}{
+		{"unchanged", "a", "a", false, false},
+		{"documents changed", "a", "b", false, true},
+		{"triggers changed", "a", "a", true, true},
+		{"imported", collectionRolloutImportedContentHash, "b", true, false},
+		{"failed or missing generation", "", "a", false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := isCollectionRolloutContentChanged(c.oldHash, c.newHash, c.triggersChanged); actual != c.expected {
+				t.Errorf("expected %t, got %t", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseCollectionRolloutGeneration(t *testing.T) {
+	cases := []struct {
+		collectionName string
+		generation     int
+		ok             bool
+	}{
+		{"products_v1", 1, true},
+		{"products_v12", 12, true},
+		{"products", 0, false},
+		{"products_v", 0, false},
+		{"products_v1_backup", 0, false},
+		{"other_products_v1", 0, false},
+	}
+
+	for _, c := range cases {
+		generation, ok := parseCollectionRolloutGeneration("products", c.collectionName)
+		if generation != c.generation || ok != c.ok {
+			t.Errorf("%s: expected %d, %t, got %d, %t", c.collectionName, c.generation, c.ok, generation, ok)
+		}
+	}
+}
+
+func TestExpandCollectionRolloutSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceTypesenseCollectionRollout().Schema, map[string]interface{}{
+		"alias_name": "products",
+		"fields": []interface{}{
+			map[string]interface{}{"name": "title", "type": "string"},
+			map[string]interface{}{"name": "price", "type": "float", "facet": true},
+		},
+		"default_sorting_field": "price",
+	})
+
+	collectionSchema, err := expandCollectionSchema(d, "products_v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if collectionSchema.Name != "products_v1" || stringValue(collectionSchema.DefaultSortingField) != "price" {
+		t.Errorf("expected products_v1 sorted by price, got %s sorted by %s", collectionSchema.Name, stringValue(collectionSchema.DefaultSortingField))
+	}
+
+	if len(collectionSchema.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(collectionSchema.Fields))
+	}
+
+	for _, field := range collectionSchema.Fields {
+		if field.Drop != nil {
+			t.Errorf("expected %s not to be dropped", field.Name)
+		}
+	}
+
+	if price := collectionSchema.Fields[1]; price.Name != "price" || !boolValue(price.Facet) {
+		t.Errorf("expected a facetable price field, got %+v", price)
+	}
+}
@@ -0,0 +1,99 @@
+package typesense
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffCollectionFields(t *testing.T) {
+	cases := []struct {
+		name      string
+		oldFields []collectionField
+		newFields []collectionField
+		expected  []collectionField
+	}{
+		{
+			name:      "unchanged",
+			oldFields: []collectionField{{Name: "title", Type: "string"}},
+			newFields: []collectionField{{Name: "title", Type: "string"}},
+			expected:  []collectionField{},
+		},
+		{
+			name:      "added",
+			oldFields: []collectionField{{Name: "title", Type: "string"}},
+			newFields: []collectionField{{Name: "title", Type: "string"}, {Name: "price", Type: "float"}},
+			expected:  []collectionField{{Name: "price", Type: "float"}},
+		},
+		{
+			name:      "removed",
+			oldFields: []collectionField{{Name: "title", Type: "string"}, {Name: "price", Type: "float"}},
+			newFields: []collectionField{{Name: "title", Type: "string"}},
+			expected:  []collectionField{{Name: "price", Drop: boolPointer(true)}},
+		},
+		{
+			name:      "changed",
+			oldFields: []collectionField{{Name: "title", Type: "string", Facet: boolPointer(false)}, {Name: "price", Type: "float"}},
+			newFields: []collectionField{{Name: "title", Type: "string", Facet: boolPointer(true)}, {Name: "price", Type: "float"}},
+			expected:  []collectionField{{Name: "title", Drop: boolPointer(true)}, {Name: "title", Type: "string", Facet: boolPointer(true)}},
+		},
+		{
+			name:      "attribute left to the server",
+			oldFields: []collectionField{{Name: "title", Type: "string", Sort: boolPointer(false)}},
+			newFields: []collectionField{{Name: "title", Type: "string"}},
+			expected:  []collectionField{},
+		},
+		{
+			name:      "reference removed",
+			oldFields: []collectionField{{Name: "product_id", Type: "string", Reference: stringPointer("products.id")}},
+			newFields: []collectionField{{Name: "product_id", Type: "string"}},
+			expected:  []collectionField{{Name: "product_id", Drop: boolPointer(true)}, {Name: "product_id", Type: "string"}},
+		},
+		{
+			name:      "index removed",
+			oldFields: []collectionField{{Name: "title", Type: "string", Index: boolPointer(false)}},
+			newFields: []collectionField{{Name: "title", Type: "string"}},
+			expected:  []collectionField{{Name: "title", Drop: boolPointer(true)}, {Name: "title", Type: "string"}},
+		},
+		{
+			name:      "index left at its default",
+			oldFields: []collectionField{{Name: "title", Type: "string", Index: boolPointer(true)}},
+			newFields: []collectionField{{Name: "title", Type: "string"}},
+			expected:  []collectionField{},
+		},
+		{
+			name:      "marked with drop",
+			oldFields: []collectionField{{Name: "title", Type: "string"}, {Name: "tags", Type: "string[]"}},
+			newFields: []collectionField{{Name: "title", Type: "string"}, {Name: "tags", Type: "string[]", Drop: boolPointer(true)}},
+			expected:  []collectionField{{Name: "tags", Drop: boolPointer(true)}},
+		},
+		{
+			name:      "already dropped",
+			oldFields: []collectionField{{Name: "tags", Type: "string[]", Drop: boolPointer(true)}},
+			newFields: []collectionField{{Name: "tags", Type: "string[]", Drop: boolPointer(true)}},
+			expected:  []collectionField{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := diffCollectionFields(c.oldFields, c.newFields); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSortCollectionFieldsByPrior(t *testing.T) {
+	field := func(name string) interface{} {
+		return map[string]interface{}{"name": name}
+	}
+
+	// The server appended the added "brand" and the changed "title" to the end of its list.
+	fields := []interface{}{field("price"), field("auto"), field("brand"), field("title")}
+	prior := []interface{}{field("title"), field("brand"), field("price")}
+
+	expected := []interface{}{field("title"), field("brand"), field("price"), field("auto")}
+	if actual := sortCollectionFieldsByPrior(fields, prior); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -3,14 +3,17 @@ package typesense
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/typesense/typesense-go/typesense"
-	"github.com/typesense/typesense-go/typesense/api"
 )
 
+// curationRuleConditions are the attributes of a rule that decide when the curation applies, at
+// least one of them must be set.
+var curationRuleConditions = []string{"rule.0.query", "rule.0.filter_by", "rule.0.tags"}
+
 func resourceTypesenseCuration() *schema.Resource {
 	return &schema.Resource{
 		Description: "Promote or exclude certain documents from a query result",
@@ -28,19 +31,39 @@ func resourceTypesenseCuration() *schema.Resource {
 			},
 			"rule": {
 				Type:        schema.TypeList,
-				Description: "Rule of this curation",
+				Description: "Rule of this curation. It can match a query, a filter, tags or a combination of them, at least one of which is required",
 				Required:    true,
 				MaxItems:    1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"query": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Description:  "Query to match",
+							Optional:     true,
+							RequiredWith: []string{"rule.0.match"},
+							AtLeastOneOf: curationRuleConditions,
 						},
 						"match": {
 							Type:         schema.TypeString,
-							Required:     true,
+							Description:  "Whether the query should match `exact`ly or only be `contains`ed in the search query. Required with `query`",
+							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"exact", "contains"}, false),
+							RequiredWith: []string{"rule.0.query"},
+						},
+						"filter_by": {
+							Type:         schema.TypeString,
+							Description:  "Filter the search request must contain for the curation to apply",
+							Optional:     true,
+							AtLeastOneOf: curationRuleConditions,
+						},
+						"tags": {
+							Type:         schema.TypeSet,
+							Description:  "Tags that trigger the curation when they are passed with the `override_tags` search parameter",
+							Optional:     true,
+							AtLeastOneOf: curationRuleConditions,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
 						},
 					},
 				},
@@ -79,7 +102,78 @@ func resourceTypesenseCuration() *schema.Resource {
 					},
 				},
 			},
+			"filter_by": {
+				Type:        schema.TypeString,
+				Description: "Filter applied to the search results when the curation matches",
+				Optional:    true,
+			},
+			"sort_by": {
+				Type:        schema.TypeString,
+				Description: "Sort order applied to the search results when the curation matches",
+				Optional:    true,
+			},
+			"replace_query": {
+				Type:        schema.TypeString,
+				Description: "Query that replaces the search query when the curation matches",
+				Optional:    true,
+			},
+			"remove_matched_tokens": {
+				Type:        schema.TypeBool,
+				Description: "Whether the tokens matching the rule are removed from the search query",
+				Optional:    true,
+				Default:     true,
+			},
+			"filter_curated_hits": {
+				Type:        schema.TypeBool,
+				Description: "Whether the filters of the search request are also applied to the included documents",
+				Optional:    true,
+				Default:     false,
+			},
+			"effective_from_ts": {
+				Type:          schema.TypeInt,
+				Description:   "Unix timestamp from which the curation is in effect",
+				Optional:      true,
+				ConflictsWith: []string{"effective_from"},
+				ValidateFunc:  validation.IntAtLeast(0),
+			},
+			"effective_to_ts": {
+				Type:          schema.TypeInt,
+				Description:   "Unix timestamp until which the curation is in effect",
+				Optional:      true,
+				ConflictsWith: []string{"effective_to"},
+				ValidateFunc:  validation.IntAtLeast(0),
+			},
+			"effective_from": {
+				Type:             schema.TypeString,
+				Description:      "RFC3339 time from which the curation is in effect, such as `2024-06-01T00:00:00Z`",
+				Optional:         true,
+				ConflictsWith:    []string{"effective_from_ts"},
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimeDiffs,
+			},
+			"effective_to": {
+				Type:             schema.TypeString,
+				Description:      "RFC3339 time until which the curation is in effect, such as `2024-09-01T00:00:00Z`",
+				Optional:         true,
+				ConflictsWith:    []string{"effective_to_ts"},
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimeDiffs,
+			},
+			"stop_processing": {
+				Type:        schema.TypeBool,
+				Description: "Whether the remaining curations are skipped when this curation matches",
+				Optional:    true,
+				Default:     true,
+			},
+			"metadata": {
+				Type:             schema.TypeString,
+				Description:      "Free-form JSON object returned with the search results when the curation matches",
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
 		},
+		CustomizeDiff: resourceTypesenseCurationCustomizeDiff,
 		ReadContext:   resourceTypesenseCurationRead,
 		CreateContext: resourceTypesenseCurationUpsert,
 		UpdateContext: resourceTypesenseCurationUpsert,
@@ -87,32 +181,47 @@ func resourceTypesenseCuration() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceTypesenseCurationState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
 func resourceTypesenseCurationUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	name := d.Get("name").(string)
 	collectionName := d.Get("collection_name").(string)
-	overwriteSchema := &api.SearchOverrideSchema{}
+	overrideSchema := &searchOverrideSchema{
+		FilterBy:            nonEmptyStringPointer(d.Get("filter_by").(string)),
+		SortBy:              nonEmptyStringPointer(d.Get("sort_by").(string)),
+		ReplaceQuery:        nonEmptyStringPointer(d.Get("replace_query").(string)),
+		RemoveMatchedTokens: boolPointer(d.Get("remove_matched_tokens").(bool)),
+		FilterCuratedHits:   boolPointer(d.Get("filter_curated_hits").(bool)),
+		StopProcessing:      boolPointer(d.Get("stop_processing").(bool)),
+	}
 
-	if vs := d.Get("rule").([]interface{}); len(vs) > 0 {
+	if vs := d.Get("rule").([]interface{}); len(vs) > 0 && vs[0] != nil {
 		rule := vs[0].(map[string]interface{})
 
-		overwriteSchema.Rule = api.SearchOverrideRule{
-			Match: rule["match"].(string),
-			Query: rule["query"].(string),
+		overrideSchema.Rule = searchOverrideRule{
+			Query:    rule["query"].(string),
+			Match:    rule["match"].(string),
+			FilterBy: rule["filter_by"].(string),
+			Tags:     interfaceArrayToStringArray(rule["tags"].(*schema.Set).List()),
 		}
 	}
 
 	if vs := d.Get("includes").([]interface{}); len(vs) > 0 {
-		includes := make([]api.SearchOverrideInclude, len(vs))
+		includes := make([]searchOverrideInclude, len(vs))
 
 		for i, v := range vs {
 			r := v.(map[string]interface{})
 
-			include := api.SearchOverrideInclude{
+			include := searchOverrideInclude{
 				Id: r["id"].(string),
 			}
 
@@ -123,23 +232,41 @@ func resourceTypesenseCurationUpsert(ctx context.Context, d *schema.ResourceData
 			includes[i] = include
 		}
 
-		overwriteSchema.Includes = includes
+		overrideSchema.Includes = includes
 	}
 
 	if vs := d.Get("excludes").([]interface{}); len(vs) > 0 {
-		excludes := make([]api.SearchOverrideExclude, len(vs))
+		excludes := make([]searchOverrideExclude, len(vs))
 
 		for i, v := range vs {
 			r := v.(map[string]interface{})
-			excludes[i] = api.SearchOverrideExclude{
+			excludes[i] = searchOverrideExclude{
 				Id: r["id"].(string),
 			}
 		}
 
-		overwriteSchema.Excludes = excludes
+		overrideSchema.Excludes = excludes
 	}
 
-	override, err := client.Collection(collectionName).Overrides().Upsert(name, overwriteSchema)
+	from, err := expandCurationEffectiveTime(d.Get("effective_from_ts").(int), d.Get("effective_from").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	overrideSchema.EffectiveFromTs = from
+
+	to, err := expandCurationEffectiveTime(d.Get("effective_to_ts").(int), d.Get("effective_to").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	overrideSchema.EffectiveToTs = to
+
+	metadata, err := expandJSONObject(d.Get("metadata").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	overrideSchema.Metadata = metadata
+
+	override, err := client.upsertOverride(ctx, collectionName, name, overrideSchema)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -149,7 +276,7 @@ func resourceTypesenseCurationUpsert(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceTypesenseCurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -158,10 +285,9 @@ func resourceTypesenseCurationRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	override, err := client.Collection(collectionName).Override(id).Retrieve()
+	override, err := client.retrieveOverride(ctx, collectionName, id)
 	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
+		return readDiagnostics(d, err)
 	}
 
 	if err := d.Set("name", override.Id); err != nil {
@@ -188,11 +314,49 @@ func resourceTypesenseCurationRead(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
+	if err := flattenCurationSettings(d, override); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenCurationEffectiveTime(d, "effective_from_ts", "effective_from", override.EffectiveFromTs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenCurationEffectiveTime(d, "effective_to_ts", "effective_to", override.EffectiveToTs); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
+func resourceTypesenseCurationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Times that depend on other resources are only validated once they are known.
+	for _, key := range []string{"effective_from_ts", "effective_from", "effective_to_ts", "effective_to"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	from, err := expandCurationEffectiveTime(d.Get("effective_from_ts").(int), d.Get("effective_from").(string))
+	if err != nil {
+		return err
+	}
+
+	to, err := expandCurationEffectiveTime(d.Get("effective_to_ts").(int), d.Get("effective_to").(string))
+	if err != nil {
+		return err
+	}
+
+	if from != nil && to != nil && *from >= *to {
+		return fmt.Errorf("the curation must start before it ends, got %s and %s",
+			time.Unix(*from, 0).UTC().Format(time.RFC3339), time.Unix(*to, 0).UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 func resourceTypesenseCurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -201,8 +365,7 @@ func resourceTypesenseCurationDelete(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	_, err = client.Collection(collectionName).Override(id).Delete()
-	if err != nil {
+	if err := client.deleteOverride(ctx, collectionName, id); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -211,14 +374,14 @@ func resourceTypesenseCurationDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceTypesenseCurationState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
-	collectionName, id, err := splitCollectionRelatedId(d.Id(), "alias")
+	collectionName, id, err := splitCollectionRelatedId(d.Id(), "curation")
 	if err != nil {
 		return nil, err
 	}
 
-	override, err := client.Collection(collectionName).Override(id).Retrieve()
+	override, err := client.retrieveOverride(ctx, collectionName, id)
 	if err != nil {
 		return nil, err
 	}
@@ -227,16 +390,18 @@ func resourceTypesenseCurationState(ctx context.Context, d *schema.ResourceData,
 	return []*schema.ResourceData{d}, nil
 }
 
-func flattenCurationRule(rule api.SearchOverrideRule) []interface{} {
-	res := []interface{}{}
-	res[0] = map[string]interface{}{
-		"match": rule.Match,
-		"query": rule.Query,
+func flattenCurationRule(rule searchOverrideRule) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"query":     rule.Query,
+			"match":     rule.Match,
+			"filter_by": rule.FilterBy,
+			"tags":      rule.Tags,
+		},
 	}
-	return res
 }
 
-func flattenCurationIncludes(includes []api.SearchOverrideInclude) []interface{} {
+func flattenCurationIncludes(includes []searchOverrideInclude) []interface{} {
 	ins := make([]interface{}, len(includes))
 
 	for i, include := range includes {
@@ -253,7 +418,7 @@ func flattenCurationIncludes(includes []api.SearchOverrideInclude) []interface{}
 	return ins
 }
 
-func flattenCurationExcludes(excludes []api.SearchOverrideExclude) []interface{} {
+func flattenCurationExcludes(excludes []searchOverrideExclude) []interface{} {
 	exs := make([]interface{}, len(excludes))
 
 	for i, exclude := range excludes {
@@ -264,3 +429,85 @@ func flattenCurationExcludes(excludes []api.SearchOverrideExclude) []interface{}
 
 	return exs
 }
+
+// flattenCurationSettings sets the attributes shared by the curation resource and data source
+// other than the rule, includes, excludes and effective times.
+func flattenCurationSettings(d *schema.ResourceData, override *searchOverride) error {
+	if err := d.Set("filter_by", stringValue(override.FilterBy)); err != nil {
+		return err
+	}
+
+	if err := d.Set("sort_by", stringValue(override.SortBy)); err != nil {
+		return err
+	}
+
+	if err := d.Set("replace_query", stringValue(override.ReplaceQuery)); err != nil {
+		return err
+	}
+
+	// Older servers don't return the flags, so the configured values are kept in that case.
+	if override.RemoveMatchedTokens != nil {
+		if err := d.Set("remove_matched_tokens", *override.RemoveMatchedTokens); err != nil {
+			return err
+		}
+	}
+
+	if override.FilterCuratedHits != nil {
+		if err := d.Set("filter_curated_hits", *override.FilterCuratedHits); err != nil {
+			return err
+		}
+	}
+
+	if override.StopProcessing != nil {
+		if err := d.Set("stop_processing", *override.StopProcessing); err != nil {
+			return err
+		}
+	}
+
+	metadata, err := flattenJSON(override.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("metadata", metadata)
+}
+
+// expandCurationEffectiveTime converts an effective time given either as a unix timestamp or
+// as an RFC3339 string to the unix timestamp sent to the server.
+func expandCurationEffectiveTime(ts int, rfc3339 string) (*int64, error) {
+	if rfc3339 != "" {
+		t, err := time.Parse(time.RFC3339, rfc3339)
+		if err != nil {
+			return nil, err
+		}
+
+		unix := t.Unix()
+		return &unix, nil
+	}
+
+	if ts > 0 {
+		unix := int64(ts)
+		return &unix, nil
+	}
+
+	return nil, nil
+}
+
+// flattenCurationEffectiveTime sets an effective time in the format it is configured with.
+// The unix timestamp is used when neither is set, such as on import.
+func flattenCurationEffectiveTime(d *schema.ResourceData, tsKey, timeKey string, ts *int64) error {
+	if d.Get(timeKey).(string) != "" {
+		if err := d.Set(tsKey, 0); err != nil {
+			return err
+		}
+
+		return d.Set(timeKey, formatUnixTime(ts))
+	}
+
+	var v int
+	if ts != nil {
+		v = int(*ts)
+	}
+
+	return d.Set(tsKey, v)
+}
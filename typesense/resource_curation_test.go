@@ -0,0 +1,38 @@
+package typesense
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceTypesenseCurationRuleValidation(t *testing.T) {
+	cases := []struct {
+		name  string
+		rule  map[string]interface{}
+		valid bool
+	}{
+		{"query", map[string]interface{}{"query": "apple", "match": "exact"}, true},
+		{"filter", map[string]interface{}{"filter_by": "category:=phones"}, true},
+		{"tags", map[string]interface{}{"tags": []interface{}{"sale"}}, true},
+		{"query and filter", map[string]interface{}{"query": "apple", "match": "contains", "filter_by": "category:=phones"}, true},
+		{"no condition", map[string]interface{}{}, false},
+		{"query without match", map[string]interface{}{"query": "apple"}, false},
+		{"match without query", map[string]interface{}{"match": "exact", "filter_by": "category:=phones"}, false},
+		{"invalid match", map[string]interface{}{"query": "apple", "match": "prefix"}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := resourceTypesenseCuration().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":            "apple",
+				"collection_name": "products",
+				"rule":            []interface{}{c.rule},
+			}))
+
+			if valid := !diags.HasError(); valid != c.valid {
+				t.Errorf("expected valid to be %t, got %v", c.valid, diags)
+			}
+		})
+	}
+}
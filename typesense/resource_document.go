@@ -2,11 +2,15 @@ package typesense
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"reflect"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceTypesenseDocument() *schema.Resource {
@@ -17,29 +21,59 @@ func resourceTypesenseDocument() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Name of the collection",
 				Required:    true,
+				ForceNew:    true,
 			},
 			"document": {
-				Type:        schema.TypeMap,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Document's body",
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Description:  "Document's body",
+				Deprecated:   "Use document_json, which supports every field type",
+				ExactlyOneOf: []string{"document", "document_json"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"document_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Document's body as a JSON object, such as `jsonencode({ id = \"1\", price = 9.99 })`. It must have a string `id`",
+				ExactlyOneOf:     []string{"document", "document_json"},
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Description:  "How the document is written on creation, one of `create`, `upsert`, `update` or `emplace`. With `update` and `emplace`, changes only patch the changed keys and keep the other fields of the document, otherwise the whole document is replaced",
+				Optional:     true,
+				Default:      "upsert",
+				ValidateFunc: validation.StringInSlice([]string{"create", "upsert", "update", "emplace"}, false),
+			},
+			"dirty_values": {
+				Type:         schema.TypeString,
+				Description:  "How values that don't match the field type are handled, one of `coerce_or_reject`, `coerce_or_drop`, `drop` or `reject`",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"coerce_or_reject", "coerce_or_drop", "drop", "reject"}, false),
+			},
 		},
+		CustomizeDiff: resourceTypesenseDocumentCustomizeDiff,
 		ReadContext:   resourceTypesenseDocumentRead,
-		CreateContext: resourceTypesenseDocumentUpsert,
-		UpdateContext: resourceTypesenseDocumentUpsert,
+		CreateContext: resourceTypesenseDocumentCreate,
+		UpdateContext: resourceTypesenseDocumentUpdate,
 		DeleteContext: resourceTypesenseDocumentDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceTypesenseDocumentState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
-func resourceTypesenseDocumentUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+func resourceTypesenseDocumentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
 
 	var collectionName string
 
@@ -47,48 +81,107 @@ func resourceTypesenseDocumentUpsert(ctx context.Context, d *schema.ResourceData
 		collectionName = v.(string)
 	}
 
-	var document map[string]interface{}
-
-	if v, ok := d.GetOk("document"); ok {
-		document = v.(map[string]interface{})
+	document, err := expandDocument(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	var id string
-	if v, ok := document["id"]; ok {
-		id = v.(string)
-	} else {
+	id, ok := document["id"].(string)
+	if !ok || id == "" {
 		return diag.Errorf("id required for document")
 	}
 
-	_, err := client.Collection(collectionName).Documents().Create(document)
-	if err != nil {
+	params := documentWriteParams(d)
+	params.Set("action", d.Get("action").(string))
+
+	if _, err := client.indexDocument(ctx, collectionName, params, document); err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s.%s", collectionName, id))
-	return resourceTypesenseCurationRead(ctx, d, meta)
+	return resourceTypesenseDocumentRead(ctx, d, meta)
 }
 
-func resourceTypesenseDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+func resourceTypesenseDocumentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
 
-	var diags diag.Diagnostics
+	if !d.HasChanges("document", "document_json") {
+		return resourceTypesenseDocumentRead(ctx, d, meta)
+	}
 
 	collectionName, id, err := splitCollectionRelatedId(d.Id(), "document")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	doc, err := client.Collection(collectionName).Document(id).Retrieve()
+	document, err := expandDocument(d)
 	if err != nil {
-		d.SetId("")
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("document", doc); err != nil {
+	params := documentWriteParams(d)
+
+	switch d.Get("action").(string) {
+	case "update", "emplace":
+		oldDocument, err := expandOldDocument(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if patch := diffDocuments(oldDocument, document); len(patch) > 0 {
+			if err := client.updateDocument(ctx, collectionName, id, params, patch); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	default:
+		// A document that already exists can't be created again, so it is replaced instead.
+		params.Set("action", "upsert")
+
+		if _, err := client.indexDocument(ctx, collectionName, params, document); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTypesenseDocumentRead(ctx, d, meta)
+}
+
+func resourceTypesenseDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	collectionName, id, err := splitCollectionRelatedId(d.Id(), "document")
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	doc, err := client.retrieveDocument(ctx, collectionName, id)
+	if err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	// Only the keys managed by this resource are compared, so fields written by others don't
+	// cause diffs. Every key is kept when nothing is managed yet, such as on import.
+	if v := d.Get("document").(map[string]interface{}); len(v) > 0 {
+		if err := d.Set("document", flattenDocumentStrings(filterDocumentKeys(doc, v))); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		prior, err := expandJSONObject(d.Get("document_json").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		documentJSON, err := flattenJSON(filterDocumentKeys(doc, prior))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("document_json", documentJSON); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if err := d.Set("collection_name", collectionName); err != nil {
 		return diag.FromErr(err)
 	}
@@ -96,8 +189,43 @@ func resourceTypesenseDocumentRead(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
+func resourceTypesenseDocumentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	// The id identifies the document, so changing it replaces the document instead of upserting a new one.
+	if d.HasChange("document_json") && d.NewValueKnown("document_json") {
+		o, n := d.GetChange("document_json")
+
+		oldDocument, err := expandJSONObject(o.(string))
+		if err != nil {
+			return err
+		}
+
+		newDocument, err := expandJSONObject(n.(string))
+		if err != nil {
+			return err
+		}
+
+		if oldDocument["id"] != newDocument["id"] {
+			return d.ForceNew("document_json")
+		}
+	}
+
+	if d.HasChange("document") && d.NewValueKnown("document") {
+		o, n := d.GetChange("document")
+
+		if o.(map[string]interface{})["id"] != n.(map[string]interface{})["id"] {
+			return d.ForceNew("document")
+		}
+	}
+
+	return nil
+}
+
 func resourceTypesenseDocumentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -106,8 +234,7 @@ func resourceTypesenseDocumentDelete(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	_, err = client.Collection(collectionName).Document(id).Delete()
-	if err != nil {
+	if err := client.deleteDocument(ctx, collectionName, id); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -116,14 +243,14 @@ func resourceTypesenseDocumentDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceTypesenseDocumentState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	collectionName, id, err := splitCollectionRelatedId(d.Id(), "document")
 	if err != nil {
 		return nil, err
 	}
 
-	doc, err := client.Collection(collectionName).Document(id).Retrieve()
+	doc, err := client.retrieveDocument(ctx, collectionName, id)
 	if err != nil {
 		return nil, err
 	}
@@ -131,3 +258,90 @@ func resourceTypesenseDocumentState(ctx context.Context, d *schema.ResourceData,
 	d.SetId(fmt.Sprintf("%s.%s", collectionName, doc["id"]))
 	return []*schema.ResourceData{d}, nil
 }
+
+// expandDocument returns the configured document. Numbers in document_json are kept as
+// json.Number so that large integers keep their precision.
+func expandDocument(d *schema.ResourceData) (map[string]interface{}, error) {
+	if v := d.Get("document_json").(string); v != "" {
+		return decodeDocument([]byte(v))
+	}
+
+	return d.Get("document").(map[string]interface{}), nil
+}
+
+// expandOldDocument returns the document as it was before the current change.
+func expandOldDocument(d *schema.ResourceData) (map[string]interface{}, error) {
+	if o, _ := d.GetChange("document_json"); o.(string) != "" {
+		return decodeDocument([]byte(o.(string)))
+	}
+
+	o, _ := d.GetChange("document")
+	return o.(map[string]interface{}), nil
+}
+
+// diffDocuments returns the keys of newDocument whose values changed. Removed keys are set to
+// null, which removes them from the stored document.
+func diffDocuments(oldDocument, newDocument map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+
+	for k, v := range newDocument {
+		if o, ok := oldDocument[k]; !ok || !reflect.DeepEqual(o, v) {
+			patch[k] = v
+		}
+	}
+
+	for k := range oldDocument {
+		if _, ok := newDocument[k]; !ok && k != "id" {
+			patch[k] = nil
+		}
+	}
+
+	return patch
+}
+
+func documentWriteParams(d *schema.ResourceData) url.Values {
+	params := url.Values{}
+	if v := d.Get("dirty_values").(string); v != "" {
+		params.Set("dirty_values", v)
+	}
+
+	return params
+}
+
+// filterDocumentKeys returns the keys of doc that are in keys, or doc itself when keys is empty.
+func filterDocumentKeys(doc map[string]interface{}, keys map[string]interface{}) map[string]interface{} {
+	if len(keys) == 0 {
+		return doc
+	}
+
+	res := map[string]interface{}{}
+	for k := range keys {
+		if v, ok := doc[k]; ok {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+// flattenDocumentStrings converts the values of a document to the strings of the document map.
+// Values other than strings are encoded as JSON.
+func flattenDocumentStrings(doc map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(doc))
+	for k, v := range doc {
+		if str, ok := v.(string); ok {
+			res[k] = str
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			res[k] = fmt.Sprint(v)
+			continue
+		}
+
+		res[k] = string(b)
+	}
+
+	return res
+}
@@ -0,0 +1,39 @@
+package typesense
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	oldDocument := map[string]interface{}{
+		"id":     "1",
+		"title":  "Old",
+		"price":  10,
+		"tags":   []interface{}{"a", "b"},
+		"remove": true,
+	}
+	newDocument := map[string]interface{}{
+		"id":    "1",
+		"title": "New",
+		"price": 10,
+		"tags":  []interface{}{"a", "b"},
+		"added": "value",
+	}
+
+	expected := map[string]interface{}{
+		"title":  "New",
+		"added":  "value",
+		"remove": nil,
+	}
+	if actual := diffDocuments(oldDocument, newDocument); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestDiffDocumentsKeepsId(t *testing.T) {
+	actual := diffDocuments(map[string]interface{}{"id": "1"}, map[string]interface{}{})
+	if len(actual) != 0 {
+		t.Errorf("expected no changes, got %v", actual)
+	}
+}
@@ -0,0 +1,98 @@
+package typesense
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTypesenseDocumentsDeleteByFilter() *schema.Resource {
+	return &schema.Resource{
+		Description: "Deletes the documents matching a filter when it is created, and again whenever `triggers` change. Destroying it doesn't restore any document.",
+		Schema: map[string]*schema.Schema{
+			"collection_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the collection",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"filter_by": {
+				Type:        schema.TypeString,
+				Description: "Filter of the documents to delete, such as `expires_at:<1700000000`",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Description:  "Number of documents deleted at a time by the server",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that run the deletion again when they change, such as a timestamp",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"num_deleted": {
+				Type:        schema.TypeInt,
+				Description: "Number of documents deleted by the last run",
+				Computed:    true,
+			},
+		},
+		ReadContext:   resourceTypesenseDocumentsDeleteByFilterRead,
+		CreateContext: resourceTypesenseDocumentsDeleteByFilterCreate,
+		DeleteContext: resourceTypesenseDocumentsDeleteByFilterDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesenseDocumentsDeleteByFilterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	collectionName := d.Get("collection_name").(string)
+
+	numDeleted, err := client.deleteDocumentsByFilter(ctx, collectionName, d.Get("filter_by").(string), d.Get("batch_size").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("num_deleted", numDeleted); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Every run is a new resource, so the id only has to be unique.
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%d", collectionName, d.Get("filter_by"), time.Now().UnixNano())))
+	d.SetId(fmt.Sprintf("%s.%s", collectionName, hex.EncodeToString(sum[:8])))
+	return diags
+}
+
+// resourceTypesenseDocumentsDeleteByFilterRead does nothing, because the deletion has no state on the server.
+func resourceTypesenseDocumentsDeleteByFilterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+// resourceTypesenseDocumentsDeleteByFilterDelete only removes the resource from the state.
+func resourceTypesenseDocumentsDeleteByFilterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,395 @@
+package typesense
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// documentsDeleteChunkSize is the number of ids deleted by a single filter_by request.
+	documentsDeleteChunkSize = 100
+	// maxDocumentsImportDiagnostics limits the failed lines reported one by one.
+	maxDocumentsImportDiagnostics = 20
+)
+
+func resourceTypesenseDocumentsImport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Documents imported in bulk from JSONL. Every document must have an id without backticks, and exactly the imported documents are deleted on destroy.",
+		Schema: map[string]*schema.Schema{
+			"collection_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the collection",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"file": {
+				Type:         schema.TypeString,
+				Description:  "Path of the JSONL file to import",
+				Optional:     true,
+				ExactlyOneOf: []string{"file", "content"},
+			},
+			"content": {
+				Type:         schema.TypeString,
+				Description:  "JSONL content to import, one document per line",
+				Optional:     true,
+				ExactlyOneOf: []string{"file", "content"},
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Description:  "Import action, one of `create`, `upsert`, `update` or `emplace`",
+				Optional:     true,
+				Default:      "upsert",
+				ValidateFunc: validation.StringInSlice([]string{"create", "upsert", "update", "emplace"}, false),
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Description:  "Number of documents imported at a time by the server. Changing it doesn't import the documents again",
+				Optional:     true,
+				Default:      40,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"dirty_values": {
+				Type:         schema.TypeString,
+				Description:  "How values that don't match the field type are handled, one of `coerce_or_reject`, `coerce_or_drop`, `drop` or `reject`",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"coerce_or_reject", "coerce_or_drop", "drop", "reject"}, false),
+			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Description: "SHA-256 digest of the imported content. The documents are imported again when it changes",
+				Computed:    true,
+			},
+			"document_ids": {
+				Type:        schema.TypeList,
+				Description: "Ids of the imported documents",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: resourceTypesenseDocumentsImportCustomizeDiff,
+		ReadContext:   resourceTypesenseDocumentsImportRead,
+		CreateContext: resourceTypesenseDocumentsImportCreate,
+		UpdateContext: resourceTypesenseDocumentsImportUpdate,
+		DeleteContext: resourceTypesenseDocumentsImportDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesenseDocumentsImportCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("file") || !d.NewValueKnown("content") {
+		if err := d.SetNewComputed("content_hash"); err != nil {
+			return err
+		}
+
+		return d.SetNewComputed("document_ids")
+	}
+
+	content, err := readDocumentsImportContent(d.Get("file").(string), d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+
+	if _, err := parseDocumentsJSONL(content); err != nil {
+		return err
+	}
+
+	// The file is read here so that changes to its content are planned like any other change.
+	if hash := documentsImportContentHash(content); hash != d.Get("content_hash").(string) {
+		if err := d.SetNew("content_hash", hash); err != nil {
+			return err
+		}
+
+		return d.SetNewComputed("document_ids")
+	}
+
+	if d.HasChange("action") || d.HasChange("dirty_values") {
+		return d.SetNewComputed("document_ids")
+	}
+
+	return nil
+}
+
+func resourceTypesenseDocumentsImportCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	collectionName := d.Get("collection_name").(string)
+
+	hash, ids, _, diags := importDocumentsFromConfig(ctx, d, meta)
+	if hash == "" {
+		return diags
+	}
+
+	// The id is set even when some lines failed, so that the imported documents are tracked.
+	d.SetId(fmt.Sprintf("%s.%s", collectionName, hash[:16]))
+
+	// The hash is cleared when lines failed, so that the next apply imports the content again
+	// instead of considering it imported.
+	if diags.HasError() {
+		hash = ""
+	}
+
+	if err := d.Set("content_hash", hash); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set("document_ids", ids); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, resourceTypesenseDocumentsImportRead(ctx, d, meta)...)
+}
+
+func resourceTypesenseDocumentsImportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	// The documents themselves aren't compared, drift of the content is detected with its hash.
+	if _, err := client.retrieveCollection(ctx, d.Get("collection_name").(string)); err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	return diags
+}
+
+func resourceTypesenseDocumentsImportUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	// The batch size only affects later imports, while the other attributes change how documents
+	// are written, so the content is imported again.
+	if !d.HasChange("content_hash") && !d.HasChange("action") && !d.HasChange("dirty_values") {
+		return resourceTypesenseDocumentsImportRead(ctx, d, meta)
+	}
+
+	o, _ := d.GetChange("document_ids")
+	oldIds := interfaceArrayToStringArray(o.([]interface{}))
+
+	hash, ids, failedIds, diags := importDocumentsFromConfig(ctx, d, meta)
+	if hash == "" {
+		return diags
+	}
+
+	inContent := map[string]bool{}
+	for _, id := range ids {
+		inContent[id] = true
+	}
+
+	failed := map[string]bool{}
+	for _, id := range failedIds {
+		inContent[id] = true
+		failed[id] = true
+	}
+
+	// Documents that were removed from the content are deleted like on destroy, while those that
+	// failed to import again are still tracked.
+	removed := []string{}
+	for _, id := range oldIds {
+		if !inContent[id] {
+			removed = append(removed, id)
+		} else if failed[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	if err := deleteDocumentsByIds(ctx, client, d.Get("collection_name").(string), removed, d.Get("batch_size").(int)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	// The hash is cleared when lines failed, so that the next apply imports the content again
+	// instead of considering it imported.
+	if diags.HasError() {
+		hash = ""
+	}
+
+	if err := d.Set("content_hash", hash); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set("document_ids", ids); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, resourceTypesenseDocumentsImportRead(ctx, d, meta)...)
+}
+
+func resourceTypesenseDocumentsImportDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	ids := interfaceArrayToStringArray(d.Get("document_ids").([]interface{}))
+	if err := deleteDocumentsByIds(ctx, client, d.Get("collection_name").(string), ids, d.Get("batch_size").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// importDocumentsFromConfig imports the configured content and returns its hash and the ids of
+// the documents that were imported successfully and those that failed. The hash is empty when
+// nothing was imported.
+func importDocumentsFromConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, []string, []string, diag.Diagnostics) {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	content, err := readDocumentsImportContent(d.Get("file").(string), d.Get("content").(string))
+	if err != nil {
+		return "", nil, nil, diag.FromErr(err)
+	}
+
+	docs, err := parseDocumentsJSONL(content)
+	if err != nil {
+		return "", nil, nil, diag.FromErr(err)
+	}
+
+	params := url.Values{}
+	params.Set("action", d.Get("action").(string))
+	params.Set("batch_size", strconv.Itoa(d.Get("batch_size").(int)))
+	if v := d.Get("dirty_values").(string); v != "" {
+		params.Set("dirty_values", v)
+	}
+
+	lines := make([][]byte, len(docs))
+	for i, doc := range docs {
+		lines[i] = doc.line
+	}
+
+	results, err := client.importDocuments(ctx, d.Get("collection_name").(string), params, bytes.Join(lines, []byte("\n")))
+	if err != nil {
+		return "", nil, nil, diag.FromErr(err)
+	}
+
+	if len(results) != len(docs) {
+		return "", nil, nil, diag.Errorf("expected %d import results, got %d", len(docs), len(results))
+	}
+
+	ids := []string{}
+	failedIds := []string{}
+	for i, result := range results {
+		if result.Success {
+			ids = append(ids, docs[i].id)
+			continue
+		}
+
+		failedIds = append(failedIds, docs[i].id)
+		if len(failedIds) <= maxDocumentsImportDiagnostics {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to import document %s on line %d", docs[i].id, docs[i].lineNumber),
+				Detail:   result.Error,
+			})
+		}
+	}
+
+	if len(failedIds) > maxDocumentsImportDiagnostics {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to import %d more documents", len(failedIds)-maxDocumentsImportDiagnostics),
+		})
+	}
+
+	return documentsImportContentHash(content), ids, failedIds, diags
+}
+
+type documentsImportLine struct {
+	id         string
+	line       []byte
+	lineNumber int
+}
+
+// parseDocumentsJSONL parses the documents of JSONL content, skipping blank lines.
+// Every document must have an id so that it can be deleted again.
+func parseDocumentsJSONL(content []byte) ([]documentsImportLine, error) {
+	docs := []documentsImportLine{}
+
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		doc := struct {
+			Id interface{} `json:"id"`
+		}{}
+		if err := json.Unmarshal(line, &doc); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON: %s", i+1, err)
+		}
+
+		id, ok := doc.Id.(string)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("line %d: document must have a string id", i+1)
+		}
+
+		// Ids are quoted with backticks in filter_by when documents are deleted, which can't be escaped.
+		if strings.Contains(id, "`") {
+			return nil, fmt.Errorf("line %d: document id %q must not contain a backtick", i+1, id)
+		}
+
+		docs = append(docs, documentsImportLine{id: id, line: line, lineNumber: i + 1})
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no documents to import")
+	}
+
+	return docs, nil
+}
+
+func readDocumentsImportContent(file, content string) ([]byte, error) {
+	if file != "" {
+		return ioutil.ReadFile(file)
+	}
+
+	return []byte(content), nil
+}
+
+func documentsImportContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// deleteDocumentsByIds deletes documents in chunks of filter_by requests on their ids.
+func deleteDocumentsByIds(ctx context.Context, client *typesenseClient, collectionName string, ids []string, batchSize int) error {
+	for start := 0; start < len(ids); start += documentsDeleteChunkSize {
+		end := start + documentsDeleteChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		quoted := make([]string, end-start)
+		for i, id := range ids[start:end] {
+			if strings.Contains(id, "`") {
+				return fmt.Errorf("document id %q can't be deleted by filter, because it contains a backtick", id)
+			}
+
+			quoted[i] = "`" + id + "`"
+		}
+
+		filterBy := fmt.Sprintf("id:[%s]", strings.Join(quoted, ","))
+		if _, err := client.deleteDocumentsByFilter(ctx, collectionName, filterBy, batchSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
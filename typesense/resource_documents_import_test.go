@@ -0,0 +1,42 @@
+package typesense
+
+import "testing"
+
+func TestParseDocumentsJSONL(t *testing.T) {
+	docs, err := parseDocumentsJSONL([]byte("{\"id\":\"eur\",\"name\":\"Euro\"}\n\n  {\"id\":\"jpy\"}  \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+
+	if docs[0].id != "eur" || docs[0].lineNumber != 1 {
+		t.Errorf("expected eur on line 1, got %s on line %d", docs[0].id, docs[0].lineNumber)
+	}
+
+	if docs[1].id != "jpy" || docs[1].lineNumber != 3 || string(docs[1].line) != `{"id":"jpy"}` {
+		t.Errorf("expected trimmed jpy on line 3, got %s on line %d: %s", docs[1].id, docs[1].lineNumber, docs[1].line)
+	}
+}
+
+func TestParseDocumentsJSONLErrors(t *testing.T) {
+	cases := map[string]string{
+		"empty":         "\n\n",
+		"invalid JSON":  "{\"id\":\"eur\"}\n{",
+		"missing id":    "{\"name\":\"Euro\"}",
+		"numeric id":    "{\"id\":1}",
+		"empty id":      "{\"id\":\"\"}",
+		"not an object": "[]",
+		"backtick id":   "{\"id\":\"a`]||id:[b\"}",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseDocumentsJSONL([]byte(content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
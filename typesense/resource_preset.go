@@ -0,0 +1,125 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTypesensePreset() *schema.Resource {
+	return &schema.Resource{
+		Description: "Set of search parameters stored on the server that can be referenced with the `preset` search parameter",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the preset",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"value": {
+				Type:             schema.TypeString,
+				Description:      "Search parameters as a JSON object, or a multi-search request with a `searches` list",
+				Required:         true,
+				ValidateFunc:     validatePresetValue,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
+		},
+		ReadContext:   resourceTypesensePresetRead,
+		CreateContext: resourceTypesensePresetUpsert,
+		UpdateContext: resourceTypesensePresetUpsert,
+		DeleteContext: resourceTypesensePresetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesensePresetUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	name := d.Get("name").(string)
+
+	value, err := expandJSONObject(d.Get("value").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	p, err := client.upsertPreset(ctx, name, &preset{Value: value})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(p.Name)
+	return resourceTypesensePresetRead(ctx, d, meta)
+}
+
+func resourceTypesensePresetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	p, err := client.retrievePreset(ctx, id)
+	if err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	if err := d.Set("name", p.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	value, err := flattenJSON(p.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("value", value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceTypesensePresetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	if err := client.deletePreset(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func validatePresetValue(i interface{}, k string) ([]string, []error) {
+	value, err := expandJSONObject(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON object: %s", k, err)}
+	}
+
+	if len(value) == 0 {
+		return nil, []error{fmt.Errorf("%q must not be empty", k)}
+	}
+
+	if searches, ok := value["searches"]; ok {
+		if _, ok := searches.([]interface{}); !ok {
+			return nil, []error{fmt.Errorf("%q: searches must be a list of search parameters", k)}
+		}
+	}
+
+	return nil, nil
+}
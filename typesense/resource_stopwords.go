@@ -0,0 +1,118 @@
+package typesense
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTypesenseStopwords() *schema.Resource {
+	return &schema.Resource{
+		Description: "Set of words removed from search queries when it is referenced with the `stopwords` search parameter",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the stopwords set",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"locale": {
+				Type:        schema.TypeString,
+				Description: "Locale of the stopwords, such as `en`",
+				Optional:    true,
+			},
+			"stopwords": {
+				Type:        schema.TypeSet,
+				Description: "Words to remove from search queries",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext:   resourceTypesenseStopwordsRead,
+		CreateContext: resourceTypesenseStopwordsUpsert,
+		UpdateContext: resourceTypesenseStopwordsUpsert,
+		DeleteContext: resourceTypesenseStopwordsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTypesenseStopwordsState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceTypesenseStopwordsUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	name := d.Get("name").(string)
+	stopwordsSchema := &stopwordsSetSchema{
+		Stopwords: interfaceArrayToStringArray(d.Get("stopwords").(*schema.Set).List()),
+		Locale:    d.Get("locale").(string),
+	}
+
+	set, err := client.upsertStopwordsSet(ctx, name, stopwordsSchema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(set.Id)
+	return resourceTypesenseStopwordsRead(ctx, d, meta)
+}
+
+func resourceTypesenseStopwordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	set, err := client.retrieveStopwordsSet(ctx, d.Id())
+	if err != nil {
+		return readDiagnostics(d, err)
+	}
+
+	if err := d.Set("name", set.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("locale", set.Locale); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("stopwords", set.Stopwords); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceTypesenseStopwordsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*typesenseClient)
+
+	var diags diag.Diagnostics
+
+	if err := client.deleteStopwordsSet(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceTypesenseStopwordsState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*typesenseClient)
+
+	set, err := client.retrieveStopwordsSet(ctx, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(set.Id)
+	return []*schema.ResourceData{d}, nil
+}
@@ -3,10 +3,10 @@ package typesense
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/typesense/typesense-go/typesense"
 	"github.com/typesense/typesense-go/typesense/api"
 )
 
@@ -46,11 +46,17 @@ func resourceTypesenseSynonyms() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceTypesenseSynonymsState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
 func resourceTypesenseSynonymsUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	name := d.Get("name").(string)
 	collectionName := d.Get("collection_name").(string)
@@ -58,21 +64,21 @@ func resourceTypesenseSynonymsUpsert(ctx context.Context, d *schema.ResourceData
 		Synonyms: interfaceArrayToStringArray(d.Get("synonyms").([]interface{})),
 	}
 
-	if v := d.Get("root"); v != nil {
-		synonymSchema.Root = v.(string)
+	if v := d.Get("root").(string); v != "" {
+		synonymSchema.Root = stringPointer(v)
 	}
 
-	synonym, err := client.Collection(collectionName).Synonyms().Upsert(name, synonymSchema)
+	synonym, err := client.withContext(ctx).Collection(collectionName).Synonyms().Upsert(name, synonymSchema)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s.%s", collectionName, synonym.Id))
-	return resourceTypesenseCurationRead(ctx, d, meta)
+	return resourceTypesenseSynonymsRead(ctx, d, meta)
 }
 
 func resourceTypesenseSynonymsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
@@ -81,10 +87,9 @@ func resourceTypesenseSynonymsRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	synonym, err := client.Collection(collectionName).Synonym(id).Retrieve()
+	synonym, err := client.withContext(ctx).Collection(collectionName).Synonym(id).Retrieve()
 	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
+		return readDiagnostics(d, err)
 	}
 
 	if err := d.Set("collection_name", collectionName); err != nil {
@@ -99,27 +104,24 @@ func resourceTypesenseSynonymsRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	if synonym.Root != "" {
-		if err := d.Set("root", synonym.Root); err != nil {
-			if err := d.Set("root", synonym.Root); err != nil {
-				return diag.FromErr(err)
-			}
-		}
+	if err := d.Set("root", stringValue(synonym.Root)); err != nil {
+		return diag.FromErr(err)
 	}
+
 	return diags
 }
 
 func resourceTypesenseSynonymsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	var diags diag.Diagnostics
 
-	collectionName, id, err := splitCollectionRelatedId(d.Id(), "document")
+	collectionName, id, err := splitCollectionRelatedId(d.Id(), "synonyms")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	_, err = client.Collection(collectionName).Synonym(id).Delete()
+	_, err = client.withContext(ctx).Collection(collectionName).Synonym(id).Delete()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -129,14 +131,14 @@ func resourceTypesenseSynonymsDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceTypesenseSynonymsState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*typesense.Client)
+	client := meta.(*typesenseClient)
 
 	collectionName, id, err := splitCollectionRelatedId(d.Id(), "synonyms")
 	if err != nil {
 		return nil, err
 	}
 
-	synonym, err := client.Collection(collectionName).Synonym(id).Retrieve()
+	synonym, err := client.withContext(ctx).Collection(collectionName).Synonym(id).Retrieve()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,158 @@
+package typesense
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+// Backoff of requests that fail with a transient error, when the retry block isn't configured.
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryMinBackoff  = 1 * time.Second
+	defaultRetryMaxBackoff  = 15 * time.Second
+)
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryDoer sends requests again with an exponential backoff when they fail with a retryable
+// status code or a network error, as far as isRetryableRequestStatus and isRetryableRequestError
+// allow it. It wraps the cluster doer, which already fails over to the other nodes, so that an
+// apply also survives a rolling restart of the whole cluster.
+type retryDoer struct {
+	doer                 api.HttpRequestDoer
+	maxAttempts          int
+	minBackoff           time.Duration
+	maxBackoff           time.Duration
+	retryableStatusCodes map[int]bool
+}
+
+func newRetryDoer(doer api.HttpRequestDoer, maxAttempts int, minBackoff, maxBackoff time.Duration, retryableStatusCodes []int) *retryDoer {
+	codes := make(map[int]bool, len(retryableStatusCodes))
+	for _, code := range retryableStatusCodes {
+		codes[code] = true
+	}
+
+	return &retryDoer{
+		doer:                 doer,
+		maxAttempts:          maxAttempts,
+		minBackoff:           minBackoff,
+		maxBackoff:           maxBackoff,
+		retryableStatusCodes: codes,
+	}
+}
+
+func (r *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	backoff := r.minBackoff
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := r.attemptRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := r.doer.Do(attemptReq)
+		if attempt >= r.maxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if err != nil {
+			if !isRetryableRequestError(req, err) {
+				return nil, err
+			}
+		} else {
+			if !r.retryableStatusCodes[resp.StatusCode] || !isRetryableRequestStatus(req, resp.StatusCode) {
+				return resp, nil
+			}
+
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
+
+// attemptRequest returns the request with a fresh body, because the body of the previous attempt
+// has already been read.
+func (r *retryDoer) attemptRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+	return attemptReq, nil
+}
+
+// isRetryableRequestError reports whether a request that failed with the error can be sent again.
+// Reads can after any network error, while writes only can when the connection couldn't be
+// established, because the server may have applied them otherwise.
+func isRetryableRequestError(req *http.Request, err error) bool {
+	if !isNetworkError(err) {
+		return false
+	}
+
+	if req.Method == http.MethodGet {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isNetworkError reports whether the connection to the server couldn't be established or broke.
+// Errors of the TLS handshake, such as an untrusted certificate, and cancelled or timed out
+// requests aren't, since sending the request again fails the same way.
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	// TLS alerts are reported as "local error" and "remote error" operations.
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op != "local error" && opErr.Op != "remote error"
+}
+
+// isRetryableRequestStatus reports whether a request that failed with the status can be sent
+// again. Reads always can, while writes only can when the server rejected them without applying
+// them, because it was overloaded or not ready yet. Sending a write again after any other error
+// could create an API key or a document twice.
+func isRetryableRequestStatus(req *http.Request, status int) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
@@ -0,0 +1,154 @@
+package typesense
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeDoer answers requests with the given status codes in turn and records the request bodies.
+type fakeDoer struct {
+	statuses []int
+	err      error
+	bodies   []string
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		f.bodies = append(f.bodies, string(b))
+	} else {
+		f.bodies = append(f.bodies, "")
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+
+	return &http.Response{StatusCode: status, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+}
+
+func newTestRetryDoer(doer *fakeDoer) *retryDoer {
+	return newRetryDoer(doer, 3, time.Millisecond, time.Millisecond, defaultRetryableStatusCodes)
+}
+
+func TestRetryDoerRetriesReads(t *testing.T) {
+	doer := &fakeDoer{statuses: []int{http.StatusBadGateway, http.StatusInternalServerError, http.StatusOK}}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8108/collections", nil)
+	resp, err := newTestRetryDoer(doer).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK || len(doer.bodies) != 3 {
+		t.Errorf("expected 200 after 3 attempts, got %d after %d", resp.StatusCode, len(doer.bodies))
+	}
+}
+
+func TestRetryDoerStopsAfterMaxAttempts(t *testing.T) {
+	doer := &fakeDoer{statuses: []int{http.StatusServiceUnavailable}}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8108/collections", nil)
+	resp, err := newTestRetryDoer(doer).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable || len(doer.bodies) != 3 {
+		t.Errorf("expected 503 after 3 attempts, got %d after %d", resp.StatusCode, len(doer.bodies))
+	}
+}
+
+func TestRetryDoerRetriesWritesOnlyWhenNotApplied(t *testing.T) {
+	cases := []struct {
+		status   int
+		attempts int
+	}{
+		{http.StatusInternalServerError, 1},
+		{http.StatusBadGateway, 1},
+		{http.StatusServiceUnavailable, 2},
+		{http.StatusTooManyRequests, 2},
+	}
+
+	for _, c := range cases {
+		doer := &fakeDoer{statuses: []int{c.status, http.StatusCreated}}
+
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost:8108/keys", bytes.NewReader([]byte(`{"actions":["*"]}`)))
+		if _, err := newTestRetryDoer(doer).Do(req); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(doer.bodies) != c.attempts {
+			t.Errorf("status %d: expected %d attempts, got %d", c.status, c.attempts, len(doer.bodies))
+		}
+
+		for _, body := range doer.bodies {
+			if body != `{"actions":["*"]}` {
+				t.Errorf("status %d: expected the body to be sent again, got %q", c.status, body)
+			}
+		}
+	}
+}
+
+func TestRetryDoerRetriesWritesOnlyOnDialErrors(t *testing.T) {
+	cases := []struct {
+		err      error
+		attempts int
+	}{
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, 3},
+		{&net.OpError{Op: "read", Err: errors.New("connection reset")}, 1},
+	}
+
+	for _, c := range cases {
+		doer := &fakeDoer{err: c.err}
+
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost:8108/keys", bytes.NewReader([]byte(`{}`)))
+		if _, err := newTestRetryDoer(doer).Do(req); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if len(doer.bodies) != c.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", c.err, c.attempts, len(doer.bodies))
+		}
+	}
+}
+
+func TestRetryDoerRetriesReadsOnlyOnNetworkErrors(t *testing.T) {
+	cases := []struct {
+		err      error
+		attempts int
+	}{
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, 3},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, 3},
+		{&url.Error{Op: "Get", Err: x509.UnknownAuthorityError{}}, 1},
+		{&net.OpError{Op: "remote error", Err: errors.New("tls: bad certificate")}, 1},
+		{&url.Error{Op: "Get", Err: context.DeadlineExceeded}, 1},
+	}
+
+	for _, c := range cases {
+		doer := &fakeDoer{err: c.err}
+
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost:8108/collections", nil)
+		if _, err := newTestRetryDoer(doer).Do(req); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if len(doer.bodies) != c.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", c.err, c.attempts, len(doer.bodies))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package typesense
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expandHTTPClient builds the HTTP client shared by every node from the TLS, proxy and header
+// options of the provider. Only connecting to a node is limited by the connection timeout, since a
+// bulk import or a collection with embedded fields may take much longer to respond. The request
+// itself is bounded by the deadline of its context, which comes from the resource timeouts.
+func expandHTTPClient(d *schema.ResourceData, connectionTimeout time.Duration) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+
+	if v := d.Get("ca_cert_pem").(string); v != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(v)) {
+			return nil, fmt.Errorf("ca_cert_pem doesn't contain any valid PEM-encoded certificate")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if v := d.Get("client_cert_pem").(string); v != "" {
+		cert, err := tls.X509KeyPair([]byte(v), []byte(d.Get("client_key_pem").(string)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %s", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.DialContext = (&net.Dialer{Timeout: connectionTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = connectionTimeout
+
+	if v := d.Get("proxy_url").(string); v != "" {
+		proxyURL, err := url.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %s", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if vs := d.Get("headers").(map[string]interface{}); len(vs) > 0 {
+		headers := http.Header{}
+		for k, v := range vs {
+			headers.Set(k, v.(string))
+		}
+
+		roundTripper = &headerTransport{transport: transport, headers: headers}
+	}
+
+	return &http.Client{Transport: roundTripper}, nil
+}
+
+// headerTransport adds the configured headers to every request, such as the credentials of an API
+// gateway in front of the cluster.
+type headerTransport struct {
+	transport http.RoundTripper
+	headers   http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given.
+	r := req.Clone(req.Context())
+	for k, v := range t.headers {
+		r.Header[k] = v
+	}
+
+	return t.transport.RoundTrip(r)
+}
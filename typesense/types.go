@@ -0,0 +1,209 @@
+package typesense
+
+// The types below mirror the Typesense API for the attributes typesense-go doesn't model yet.
+
+type collectionSchema struct {
+	Name                string                 `json:"name"`
+	Fields              []collectionField      `json:"fields"`
+	DefaultSortingField *string                `json:"default_sorting_field,omitempty"`
+	TokenSeparators     *[]string              `json:"token_separators,omitempty"`
+	SymbolsToIndex      *[]string              `json:"symbols_to_index,omitempty"`
+	EnableNestedFields  *bool                  `json:"enable_nested_fields,omitempty"`
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type collectionResponse struct {
+	collectionSchema
+
+	NumDocuments int64 `json:"num_documents"`
+	CreatedAt    int64 `json:"created_at"`
+}
+
+type collectionUpdateSchema struct {
+	Fields []collectionField `json:"fields,omitempty"`
+	// An empty object clears the metadata, so it is only omitted when nil.
+	Metadata interface{} `json:"metadata,omitempty"`
+}
+
+type collectionField struct {
+	Name           string                     `json:"name"`
+	Type           string                     `json:"type,omitempty"`
+	Facet          *bool                      `json:"facet,omitempty"`
+	Optional       *bool                      `json:"optional,omitempty"`
+	Index          *bool                      `json:"index,omitempty"`
+	Sort           *bool                      `json:"sort,omitempty"`
+	Infix          *bool                      `json:"infix,omitempty"`
+	Locale         *string                    `json:"locale,omitempty"`
+	Stem           *bool                      `json:"stem,omitempty"`
+	Store          *bool                      `json:"store,omitempty"`
+	RangeIndex     *bool                      `json:"range_index,omitempty"`
+	Reference      *string                    `json:"reference,omitempty"`
+	AsyncReference *bool                      `json:"async_reference,omitempty"`
+	NumDim         *int                       `json:"num_dim,omitempty"`
+	VecDist        *string                    `json:"vec_dist,omitempty"`
+	HnswParams     *collectionFieldHnswParams `json:"hnsw_params,omitempty"`
+	Embed          *collectionFieldEmbed      `json:"embed,omitempty"`
+	Drop           *bool                      `json:"drop,omitempty"`
+}
+
+type collectionFieldHnswParams struct {
+	EfConstruction *int `json:"ef_construction,omitempty"`
+	M              *int `json:"M,omitempty"`
+}
+
+type collectionFieldEmbed struct {
+	From        []string                        `json:"from"`
+	ModelConfig collectionFieldEmbedModelConfig `json:"model_config"`
+}
+
+type collectionFieldEmbedModelConfig struct {
+	ModelName      string  `json:"model_name"`
+	ApiKey         *string `json:"api_key,omitempty"`
+	Url            *string `json:"url,omitempty"`
+	IndexingPrefix *string `json:"indexing_prefix,omitempty"`
+	QueryPrefix    *string `json:"query_prefix,omitempty"`
+}
+
+type preset struct {
+	Name  string                 `json:"name,omitempty"`
+	Value map[string]interface{} `json:"value"`
+}
+
+type stopwordsSetSchema struct {
+	Stopwords []string `json:"stopwords"`
+	Locale    string   `json:"locale,omitempty"`
+}
+
+type stopwordsSet struct {
+	stopwordsSetSchema
+
+	Id string `json:"id"`
+}
+
+type stopwordsSetRetrieveResponse struct {
+	Stopwords stopwordsSet `json:"stopwords"`
+}
+
+type analyticsRuleSchema struct {
+	Type   string              `json:"type"`
+	Params analyticsRuleParams `json:"params"`
+}
+
+type analyticsRule struct {
+	analyticsRuleSchema
+
+	Name string `json:"name"`
+}
+
+type analyticsRuleParams struct {
+	Source      analyticsRuleSource       `json:"source"`
+	Destination *analyticsRuleDestination `json:"destination,omitempty"`
+	Limit       *int                      `json:"limit,omitempty"`
+}
+
+type analyticsRuleSource struct {
+	Collections []string             `json:"collections"`
+	Events      []analyticsRuleEvent `json:"events,omitempty"`
+}
+
+type analyticsRuleEvent struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Weight *int   `json:"weight,omitempty"`
+}
+
+type analyticsRuleDestination struct {
+	Collection   string  `json:"collection"`
+	CounterField *string `json:"counter_field,omitempty"`
+}
+
+type searchOverrideSchema struct {
+	Rule                searchOverrideRule      `json:"rule"`
+	Includes            []searchOverrideInclude `json:"includes,omitempty"`
+	Excludes            []searchOverrideExclude `json:"excludes,omitempty"`
+	FilterBy            *string                 `json:"filter_by,omitempty"`
+	SortBy              *string                 `json:"sort_by,omitempty"`
+	ReplaceQuery        *string                 `json:"replace_query,omitempty"`
+	RemoveMatchedTokens *bool                   `json:"remove_matched_tokens,omitempty"`
+	FilterCuratedHits   *bool                   `json:"filter_curated_hits,omitempty"`
+	EffectiveFromTs     *int64                  `json:"effective_from_ts,omitempty"`
+	EffectiveToTs       *int64                  `json:"effective_to_ts,omitempty"`
+	StopProcessing      *bool                   `json:"stop_processing,omitempty"`
+	Metadata            map[string]interface{}  `json:"metadata,omitempty"`
+}
+
+type searchOverride struct {
+	searchOverrideSchema
+
+	Id string `json:"id"`
+}
+
+type searchOverrideRule struct {
+	Query    string   `json:"query,omitempty"`
+	Match    string   `json:"match,omitempty"`
+	FilterBy string   `json:"filter_by,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+type searchOverrideInclude struct {
+	Id       string `json:"id"`
+	Position int    `json:"position"`
+}
+
+type searchOverrideExclude struct {
+	Id string `json:"id"`
+}
+
+type documentImportResult struct {
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+	Document string `json:"document,omitempty"`
+}
+
+type documentsDeleteResponse struct {
+	NumDeleted int `json:"num_deleted"`
+}
+
+type searchResult struct {
+	Found int                      `json:"found"`
+	Hits  []map[string]interface{} `json:"hits"`
+}
+
+// documentIds returns the ids of the documents of the hits in ranking order.
+func (r *searchResult) documentIds() []string {
+	ids := make([]string, 0, len(r.Hits))
+	for _, hit := range r.Hits {
+		document, _ := hit["document"].(map[string]interface{})
+		if id, ok := document["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+type multiSearchRequest struct {
+	Searches []map[string]interface{} `json:"searches"`
+	Union    *bool                    `json:"union,omitempty"`
+}
+
+type multiSearchResponse struct {
+	searchResult
+
+	Results []multiSearchResult `json:"results"`
+}
+
+type multiSearchResult struct {
+	searchResult
+
+	Error string `json:"error,omitempty"`
+	Code  int    `json:"code,omitempty"`
+}
+
+type healthResponse struct {
+	Ok bool `json:"ok"`
+}
+
+type searchOverridesResponse struct {
+	Overrides []searchOverride `json:"overrides"`
+}
@@ -1,8 +1,13 @@
 package typesense
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func interfaceArrayToStringArray(inputs []interface{}) []string {
@@ -22,3 +27,100 @@ func splitCollectionRelatedId(input string, resourceType string) (string, string
 
 	return eles[0], eles[1], nil
 }
+
+// suppressEquivalentJSONDiffs ignores formatting and key order differences of JSON attributes.
+func suppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
+	var o, n interface{}
+
+	if err := json.Unmarshal([]byte(old), &o); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(o, n)
+}
+
+func expandJSONObject(input string) (map[string]interface{}, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	res := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(input), &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func flattenJSON(input interface{}) (string, error) {
+	if input == nil || reflect.ValueOf(input).IsZero() {
+		return "", nil
+	}
+
+	b, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// configuredListAttributes returns the attributes set in the configuration for each element of
+// a nested block list. Optional attributes computed by the server must not be sent when they
+// aren't configured, so they can't be told apart from their zero value with d.Get alone.
+func configuredListAttributes(d *schema.ResourceData, key string) []map[string]bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+
+	list := raw.GetAttr(key)
+	if list.IsNull() || !list.IsKnown() {
+		return nil
+	}
+
+	res := []map[string]bool{}
+	for it := list.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+
+		attrs := map[string]bool{}
+		if !elem.IsNull() && elem.IsKnown() {
+			for name := range elem.Type().AttributeTypes() {
+				attrs[name] = !elem.GetAttr(name).IsNull()
+			}
+		}
+
+		res = append(res, attrs)
+	}
+
+	return res
+}
+
+// suppressEquivalentTimeDiffs ignores differences between RFC3339 times at the same instant,
+// such as the same time in different time zones.
+func suppressEquivalentTimeDiffs(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return o.Equal(n)
+}
+
+// formatUnixTime formats a unix timestamp as an RFC3339 time in UTC, or "" when it is nil.
+func formatUnixTime(ts *int64) string {
+	if ts == nil {
+		return ""
+	}
+
+	return time.Unix(*ts, 0).UTC().Format(time.RFC3339)
+}